@@ -16,6 +16,7 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/debug"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/root"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	pluginCfg "github.com/aerogear/charmil-plugin-example/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -75,7 +76,7 @@ func main() {
 	if err != nil {
 		logger.Error(wrapErrorf(err, localizer))
 		build.CheckForUpdate(context.Background(), logger, localizer)
-		os.Exit(1)
+		os.Exit(cmdutil.ExitCode(err))
 	}
 
 	if debug.Enabled() {
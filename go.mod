@@ -21,6 +21,7 @@ require (
 	github.com/redhat-developer/app-services-sdk-go/kafkamgmt v0.3.2
 	github.com/redhat-developer/app-services-sdk-go/registrymgmt v0.1.1
 	github.com/redhat-developer/service-binding-operator v0.8.0
+	github.com/segmentio/kafka-go v0.4.32
 	github.com/spf13/cobra v1.2.1
 	github.com/spf13/pflag v1.0.5
 	gitlab.com/c0b/go-ordered-json v0.0.0-20201030195603-febf46534d5a
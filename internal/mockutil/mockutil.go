@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
 	"github.com/aerogear/charmil-host-example/pkg/api"
@@ -69,3 +70,22 @@ func NewKafkaRequestTypeMock(name string) kafkamgmtclient.KafkaRequest {
 
 	return kafkaReq
 }
+
+// NewConsumerMock returns a consumer of a partition, which has no member when memberID is empty
+func NewConsumerMock(topic string, partition int32, memberID string, lag int32) kafkainstanceclient.Consumer {
+	consumer := kafkainstanceclient.Consumer{Topic: topic, Partition: partition, Lag: lag}
+	if memberID != "" {
+		consumer.SetMemberId(memberID)
+	}
+
+	return consumer
+}
+
+// NewConsumerGroupMock returns a consumer group with the given consumers
+func NewConsumerGroupMock(id string, consumers ...kafkainstanceclient.Consumer) kafkainstanceclient.ConsumerGroup {
+	var group kafkainstanceclient.ConsumerGroup
+	group.SetGroupId(id)
+	group.SetConsumers(consumers)
+
+	return group
+}
@@ -31,3 +31,17 @@ func CheckTermsAccepted(conn connection.Connection) (accepted bool, redirectURI
 
 	return false, termsReview.GetRedirectUrl(), nil
 }
+
+// CheckUserAccess performs a self access review to check if the current user
+// is allowed to perform the action on the given resource type
+func CheckUserAccess(conn connection.Connection, action string, resourceType string) (bool, error) {
+	accessReview, _, err := conn.API().AccountMgmt().
+		ApiAuthorizationsV1SelfAccessReviewPost(context.Background()).
+		SelfAccessReview(*amsclient.NewSelfAccessReview(action, resourceType)).
+		Execute()
+	if err != nil {
+		return false, err
+	}
+
+	return accessReview.GetAllowed(), nil
+}
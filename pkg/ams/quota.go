@@ -0,0 +1,143 @@
+package ams
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/api/ams/amsclient"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+)
+
+// KafkaProduct is the product of Kafka instances in the quota of an organization
+const KafkaProduct = "RHOSAK"
+
+// ApplicationServicesProducts are the products of all application services
+var ApplicationServicesProducts = []string{KafkaProduct, "RHOSAKTrial", "RHOSR", "RHOSRTrial"}
+
+// GetOrganizationID returns the ID of the organization of the current user
+func GetOrganizationID(conn connection.Connection) (string, error) {
+	account, _, err := conn.API().AccountMgmt().
+		ApiAccountsMgmtV1CurrentAccountGet(context.Background()).
+		Execute()
+	if err != nil {
+		return "", err
+	}
+
+	org := account.GetOrganization()
+
+	return org.GetId(), nil
+}
+
+// GetQuotaSummary returns the quota summary of the organization
+func GetQuotaSummary(conn connection.Connection, orgID string) ([]amsclient.QuotaSummary, error) {
+	summary, _, err := conn.API().AccountMgmt().
+		ApiAccountsMgmtV1OrganizationsOrgIdQuotaSummaryGet(context.Background(), orgID).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return summary.GetItems(), nil
+}
+
+// GetQuotaCosts returns the quota cost of the organization, including the resources which consume each quota
+func GetQuotaCosts(conn connection.Connection, orgID string) ([]amsclient.QuotaCost, error) {
+	costs, _, err := conn.API().AccountMgmt().
+		ApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGet(context.Background(), orgID).
+		FetchRelatedResources(true).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return costs.GetItems(), nil
+}
+
+// QuotaProducts returns the products which consume the quota
+func QuotaProducts(cost *amsclient.QuotaCost) []string {
+	products := []string{}
+	seen := map[string]bool{}
+	for _, r := range cost.GetRelatedResources() {
+		if !seen[r.GetProduct()] {
+			seen[r.GetProduct()] = true
+			products = append(products, r.GetProduct())
+		}
+	}
+
+	return products
+}
+
+// IsApplicationServicesQuota reports whether the quota is consumed by an application service
+func IsApplicationServicesQuota(cost *amsclient.QuotaCost) bool {
+	for _, p := range QuotaProducts(cost) {
+		if isApplicationServicesProduct(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsApplicationServicesSummary reports whether the quota summary is for an application service
+func IsApplicationServicesSummary(summary *amsclient.QuotaSummary) bool {
+	return isApplicationServicesProduct(summary.GetResourceName())
+}
+
+// KafkaQuota is the remaining Kafka instance quota of an organization
+type KafkaQuota struct {
+	Allowed  int
+	Consumed int
+	// Remaining is the number of instances which can still be created
+	Remaining int
+}
+
+// GetKafkaQuota returns the Kafka instance quota of the organization of the current user.
+// It returns nil when the organization has no quota for Kafka instances.
+func GetKafkaQuota(conn connection.Connection) (*KafkaQuota, error) {
+	orgID, err := GetOrganizationID(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	costs, err := GetQuotaCosts(conn, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range costs {
+		cost := &costs[i]
+		for _, r := range cost.GetRelatedResources() {
+			if r.GetProduct() != KafkaProduct {
+				continue
+			}
+
+			instanceCost := int(r.GetCost())
+			if instanceCost < 1 {
+				instanceCost = 1
+			}
+
+			remaining := (int(cost.GetAllowed()) - int(cost.GetConsumed())) / instanceCost
+			if remaining < 0 {
+				remaining = 0
+			}
+
+			return &KafkaQuota{
+				Allowed:   int(cost.GetAllowed()) / instanceCost,
+				Consumed:  int(cost.GetConsumed()) / instanceCost,
+				Remaining: remaining,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func isApplicationServicesProduct(product string) bool {
+	for _, p := range ApplicationServicesProducts {
+		if strings.EqualFold(p, product) {
+			return true
+		}
+	}
+
+	return false
+}
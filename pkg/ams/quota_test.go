@@ -0,0 +1,94 @@
+package ams
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/pkg/api"
+	"github.com/aerogear/charmil-host-example/pkg/api/ams/amsclient"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+)
+
+func newQuotaConnectionMock(costs []amsclient.QuotaCost) connection.Connection {
+	amsMock := &amsclient.DefaultApiMock{}
+	amsMock.ApiAccountsMgmtV1CurrentAccountGetFunc = func(ctx context.Context) amsclient.ApiApiAccountsMgmtV1CurrentAccountGetRequest {
+		return amsclient.ApiApiAccountsMgmtV1CurrentAccountGetRequest{ApiService: amsMock}
+	}
+	amsMock.ApiAccountsMgmtV1CurrentAccountGetExecuteFunc = func(r amsclient.ApiApiAccountsMgmtV1CurrentAccountGetRequest) (amsclient.Account, *http.Response, error) {
+		org := amsclient.Organization{}
+		org.SetId("org-1")
+		account := amsclient.Account{}
+		account.SetOrganization(org)
+		return account, nil, nil
+	}
+	amsMock.ApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetFunc = func(ctx context.Context, orgID string) amsclient.ApiApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetRequest {
+		return amsclient.ApiApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetRequest{ApiService: amsMock}
+	}
+	amsMock.ApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetExecuteFunc = func(r amsclient.ApiApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetRequest) (amsclient.QuotaCostList, *http.Response, error) {
+		return amsclient.QuotaCostList{Items: costs}, nil, nil
+	}
+
+	return &connection.ConnectionMock{
+		APIFunc: func() *api.API {
+			return &api.API{
+				AccountMgmt: func() amsclient.DefaultApi {
+					return amsMock
+				},
+			}
+		},
+	}
+}
+
+func newQuotaCost(allowed int32, consumed int32, product string, cost int32) amsclient.QuotaCost {
+	return amsclient.QuotaCost{
+		QuotaId:  "quota-" + product,
+		Allowed:  allowed,
+		Consumed: consumed,
+		RelatedResources: &[]amsclient.RelatedResource{
+			{Product: product, Cost: cost},
+		},
+	}
+}
+
+func TestGetKafkaQuota(t *testing.T) {
+	tests := []struct {
+		name  string
+		costs []amsclient.QuotaCost
+		want  *KafkaQuota
+	}{
+		{
+			name:  "Should return the remaining Kafka quota",
+			costs: []amsclient.QuotaCost{newQuotaCost(10, 3, "OSD", 1), newQuotaCost(5, 2, KafkaProduct, 1)},
+			want:  &KafkaQuota{Allowed: 5, Consumed: 2, Remaining: 3},
+		},
+		{
+			name:  "Should divide the quota by the cost of an instance",
+			costs: []amsclient.QuotaCost{newQuotaCost(10, 6, KafkaProduct, 2)},
+			want:  &KafkaQuota{Allowed: 5, Consumed: 3, Remaining: 2},
+		},
+		{
+			name:  "Should not return a negative remaining quota",
+			costs: []amsclient.QuotaCost{newQuotaCost(1, 2, KafkaProduct, 1)},
+			want:  &KafkaQuota{Allowed: 1, Consumed: 2, Remaining: 0},
+		},
+		{
+			name:  "Should return nil when there is no Kafka quota",
+			costs: []amsclient.QuotaCost{newQuotaCost(10, 3, "OSD", 1)},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetKafkaQuota(newQuotaConnectionMock(tt.costs))
+			if err != nil {
+				t.Fatalf("GetKafkaQuota() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetKafkaQuota() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,3 +24,14 @@ func FindByName(cloudProviders []kafkamgmtclient.CloudProvider, name string) *ka
 	}
 	return nil
 }
+
+// FilterEnabled returns the enabled cloud providers from the list
+func FilterEnabled(cloudProviders []kafkamgmtclient.CloudProvider) []kafkamgmtclient.CloudProvider {
+	enabled := []kafkamgmtclient.CloudProvider{}
+	for _, provider := range cloudProviders {
+		if provider.GetEnabled() {
+			enabled = append(enabled, provider)
+		}
+	}
+	return enabled
+}
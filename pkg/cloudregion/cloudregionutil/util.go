@@ -14,3 +14,14 @@ func GetEnabledIDs(regions []kafkamgmtclient.CloudRegion) []string {
 	}
 	return regionIDs
 }
+
+// FilterEnabled returns the enabled regions from the list
+func FilterEnabled(regions []kafkamgmtclient.CloudRegion) []kafkamgmtclient.CloudRegion {
+	enabled := []kafkamgmtclient.CloudRegion{}
+	for _, region := range regions {
+		if region.GetEnabled() {
+			enabled = append(enabled, region)
+		}
+	}
+	return enabled
+}
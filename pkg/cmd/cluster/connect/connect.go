@@ -7,9 +7,11 @@ import (
 	"github.com/aerogear/charmil-host-example/internal/build"
 	"github.com/aerogear/charmil-host-example/pkg/cluster"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
 	"github.com/aerogear/charmil-host-example/pkg/kafka"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
 	"github.com/aerogear/charmil/core/utils/iostreams"
 	"github.com/aerogear/charmil/core/utils/localize"
 	"github.com/spf13/cobra"
@@ -30,7 +32,9 @@ type Options struct {
 	offlineAccessToken      string
 	forceCreationWithoutAsk bool
 	ignoreContext           bool
+	skipPreflight           bool
 	selectedKafka           string
+	kafkaName               string
 }
 
 func NewConnectCommand(f *factory.Factory) *cobra.Command {
@@ -49,6 +53,10 @@ func NewConnectCommand(f *factory.Factory) *cobra.Command {
 		Example: opts.localizer.LocalizeByID("cluster.connect.cmd.example"),
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.kafkaName != "" && opts.ignoreContext {
+				return errors.New(opts.localizer.LocalizeByID("cluster.connect.error.kafkaNameWithIgnoreContext"))
+			}
+
 			if opts.ignoreContext == true && !opts.IO.CanPrompt() {
 				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "ignore-context")))
 			}
@@ -61,6 +69,12 @@ func NewConnectCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "", opts.localizer.LocalizeByID("cluster.common.flag.namespace.description"))
 	cmd.Flags().BoolVarP(&opts.forceCreationWithoutAsk, "yes", "y", false, opts.localizer.LocalizeByID("cluster.common.flag.yes.description"))
 	cmd.Flags().BoolVarP(&opts.ignoreContext, "ignore-context", "", false, opts.localizer.LocalizeByID("cluster.common.flag.ignoreContext.description"))
+	cmd.Flags().StringVar(&opts.kafkaName, "kafka-name", "", opts.localizer.LocalizeByID("cluster.connect.flag.kafkaName.description"))
+	preflight.AddFlag(cmd, &opts.skipPreflight, opts.localizer)
+
+	_ = cmd.RegisterFlagCompletionFunc("kafka-name", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidKafkas(f, toComplete)
+	})
 
 	return cmd
 }
@@ -76,13 +90,26 @@ func runConnect(opts *Options) error {
 		return err
 	}
 
+	// connecting to a cluster creates a service account which is bound to the Kafka instance
+	if !opts.skipPreflight {
+		if err = preflight.Check(connection, opts.localizer, preflight.CreateServiceAccount); err != nil {
+			return err
+		}
+	}
+
 	clusterConn, err := cluster.NewKubernetesClusterConnection(connection, opts.CfgHandler, logger, opts.kubeconfigLocation, opts.IO, opts.localizer)
 	if err != nil {
 		return err
 	}
 
 	// In future config will include Id's of other services
-	if opts.CfgHandler.Cfg.Services.Kafka == nil || opts.ignoreContext {
+	if opts.kafkaName != "" {
+		selectedKafka, _, err := kafka.GetKafkaByName(context.Background(), connection.API().Kafka(), opts.kafkaName)
+		if err != nil {
+			return err
+		}
+		opts.selectedKafka = selectedKafka.GetId()
+	} else if opts.CfgHandler.Cfg.Services.Kafka == nil || opts.ignoreContext {
 		// nolint
 		selectedKafka, err := kafka.InteractiveSelect(connection, logger)
 		if err != nil {
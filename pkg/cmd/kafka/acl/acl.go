@@ -0,0 +1,29 @@
+package acl
+
+import (
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/acl/delete"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/acl/grantaccess"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/acl/grantadmin"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/acl/list"
+	"github.com/spf13/cobra"
+)
+
+// NewAclCommand creates a new command sub-group for managing the access control lists of a Kafka instance
+func NewAclCommand(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   f.Localizer.LocalizeByID("kafka.acl.cmd.use"),
+		Short: f.Localizer.LocalizeByID("kafka.acl.cmd.shortDescription"),
+		Long:  f.Localizer.LocalizeByID("kafka.acl.cmd.longDescription"),
+		Args:  cobra.MinimumNArgs(1),
+	}
+
+	cmd.AddCommand(
+		list.NewListACLCommand(f),
+		grantaccess.NewGrantAccessACLCommand(f),
+		grantadmin.NewGrantAdminACLCommand(f),
+		delete.NewDeleteACLCommand(f),
+	)
+
+	return cmd
+}
@@ -0,0 +1,189 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	aclutil "github.com/aerogear/charmil-host-example/pkg/kafka/acl"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	kafkaID      string
+	principal    aclutil.PrincipalFlags
+	resourceType string
+	resourceName string
+	patternType  string
+	operation    string
+	permission   string
+	skipConfirm  bool
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewDeleteACLCommand gets a new command for deleting the ACL bindings of a Kafka instance.
+func NewDeleteACLCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.acl.delete.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.acl.delete.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.acl.delete.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.acl.delete.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.IO.CanPrompt() && !opts.skipConfirm {
+				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			if err := validateFlags(opts); err != nil {
+				return err
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.principal.ServiceAccount, "service-account", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.serviceAccount.description"))
+	cmd.Flags().StringVar(&opts.principal.User, "user", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.user.description"))
+	cmd.Flags().BoolVar(&opts.principal.AllAccounts, "all-accounts", false, opts.localizer.LocalizeByID("kafka.acl.common.flag.allAccounts.description"))
+	cmd.Flags().StringVar(&opts.resourceType, "resource-type", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.resourceType.description"))
+	cmd.Flags().StringVar(&opts.resourceName, "resource-name", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.resourceName.description"))
+	cmd.Flags().StringVar(&opts.patternType, "pattern-type", "", opts.localizer.LocalizeByID("kafka.acl.delete.flag.patternType.description"))
+	cmd.Flags().StringVar(&opts.operation, "operation", "", opts.localizer.LocalizeByID("kafka.acl.delete.flag.operation.description"))
+	cmd.Flags().StringVar(&opts.permission, "permission", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.permission.description"))
+	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.LocalizeByID("kafka.acl.delete.flag.yes.description"))
+
+	flagutil.EnableStaticFlagCompletion(cmd, "resource-type", aclutil.ValidResourceTypes)
+	flagutil.EnableStaticFlagCompletion(cmd, "pattern-type", aclutil.ValidPatternTypes)
+	flagutil.EnableStaticFlagCompletion(cmd, "operation", aclutil.ValidOperations)
+	flagutil.EnableStaticFlagCompletion(cmd, "permission", aclutil.ValidPermissions)
+
+	return cmd
+}
+
+func validateFlags(opts *Options) error {
+	if _, count := opts.principal.Principal(); count > 1 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.multiplePrincipals"))
+	}
+
+	if opts.resourceType != "" && !flagutil.IsValidInput(opts.resourceType, aclutil.ValidResourceTypes...) {
+		return flag.InvalidValueError("resource-type", opts.resourceType, aclutil.ValidResourceTypes...)
+	}
+
+	if opts.patternType != "" && !flagutil.IsValidInput(opts.patternType, aclutil.ValidPatternTypes...) {
+		return flag.InvalidValueError("pattern-type", opts.patternType, aclutil.ValidPatternTypes...)
+	}
+
+	if opts.operation != "" && !flagutil.IsValidInput(opts.operation, aclutil.ValidOperations...) {
+		return flag.InvalidValueError("operation", opts.operation, aclutil.ValidOperations...)
+	}
+
+	if opts.permission != "" && !flagutil.IsValidInput(opts.permission, aclutil.ValidPermissions...) {
+		return flag.InvalidValueError("permission", opts.permission, aclutil.ValidPermissions...)
+	}
+
+	return nil
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	principal, _ := opts.principal.Principal()
+	filter := aclutil.Filter{
+		Principal:    principal,
+		ResourceType: opts.resourceType,
+		ResourceName: opts.resourceName,
+		PatternType:  opts.patternType,
+		Operation:    opts.operation,
+		Permission:   opts.permission,
+	}
+
+	// deleting every binding of the instance would also remove the access of its owner
+	if filter.IsEmpty() {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.delete.error.filterRequired"))
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+	ctx := context.Background()
+
+	matching, httpRes, err := aclutil.FetchAll(ctx, api, filter)
+	if err != nil {
+		return aclutil.APIError(opts.localizer, httpRes, err, kafkaInstance.GetName(), "list")
+	}
+
+	if len(matching) == 0 {
+		logger.Info(opts.localizer.LocalizeByID("kafka.acl.delete.log.info.noMatchingACLs", kafkaNameTmplPair))
+		return nil
+	}
+
+	if !opts.skipConfirm {
+		fmt.Fprintln(opts.IO.ErrOut, opts.localizer.LocalizeByID("kafka.acl.delete.log.info.aclsToDelete", localize.NewEntry("Count", len(matching)), kafkaNameTmplPair))
+		dump.Table(opts.IO.ErrOut, aclutil.FromAPI(matching))
+		fmt.Fprintln(opts.IO.ErrOut)
+
+		var confirmed bool
+		promptConfirm := &survey.Confirm{
+			Message: opts.localizer.LocalizeByID("kafka.acl.delete.input.confirm.message", localize.NewEntry("Count", len(matching)), kafkaNameTmplPair),
+		}
+
+		if err = survey.AskOne(promptConfirm, &confirmed); err != nil {
+			return err
+		}
+
+		if !confirmed {
+			logger.Infoln(opts.localizer.LocalizeByID("kafka.acl.delete.log.debug.deleteNotConfirmed"))
+			return nil
+		}
+	}
+
+	deleted, httpRes, err := aclutil.Delete(ctx, api, filter)
+	if err != nil {
+		return aclutil.APIError(opts.localizer, httpRes, err, kafkaInstance.GetName(), "delete")
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.acl.delete.log.info.deleted", localize.NewEntry("Count", len(deleted)), kafkaNameTmplPair))
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package grantaccess
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	aclutil "github.com/aerogear/charmil-host-example/pkg/kafka/acl"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	kafkaID         string
+	principal       aclutil.PrincipalFlags
+	producer        bool
+	consumer        bool
+	topic           string
+	group           string
+	transactionalID string
+	cluster         bool
+	prefix          bool
+	operations      []string
+	permission      string
+	skipConfirm     bool
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewGrantAccessACLCommand gets a new command for granting a principal access to resources of a Kafka instance.
+func NewGrantAccessACLCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.acl.grantAccess.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.acl.grantAccess.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.acl.grantAccess.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.acl.grantAccess.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.IO.CanPrompt() && !opts.skipConfirm {
+				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			if err := validateFlags(opts); err != nil {
+				return err
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.principal.ServiceAccount, "service-account", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.serviceAccount.description"))
+	cmd.Flags().StringVar(&opts.principal.User, "user", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.user.description"))
+	cmd.Flags().BoolVar(&opts.principal.AllAccounts, "all-accounts", false, opts.localizer.LocalizeByID("kafka.acl.common.flag.allAccounts.description"))
+	cmd.Flags().BoolVar(&opts.producer, "producer", false, opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.producer.description"))
+	cmd.Flags().BoolVar(&opts.consumer, "consumer", false, opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.consumer.description"))
+	cmd.Flags().StringVar(&opts.topic, "topic", "", opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.topic.description"))
+	cmd.Flags().StringVar(&opts.group, "group", "", opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.group.description"))
+	cmd.Flags().StringVar(&opts.transactionalID, "transactional-id", "", opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.transactionalID.description"))
+	cmd.Flags().BoolVar(&opts.cluster, "cluster", false, opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.cluster.description"))
+	cmd.Flags().BoolVar(&opts.prefix, "prefix", false, opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.prefix.description"))
+	cmd.Flags().StringSliceVar(&opts.operations, "operation", []string{}, opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.operation.description"))
+	cmd.Flags().StringVar(&opts.permission, "permission", aclutil.PermissionAllow, opts.localizer.LocalizeByID("kafka.acl.common.flag.permission.description"))
+	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.LocalizeByID("kafka.acl.grantAccess.flag.yes.description"))
+
+	_ = cmd.RegisterFlagCompletionFunc("topic", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidTopicNameArgs(f, toComplete)
+	})
+
+	_ = cmd.RegisterFlagCompletionFunc("group", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidConsumerGroupIDs(f, toComplete)
+	})
+
+	flagutil.EnableStaticFlagCompletion(cmd, "operation", aclutil.ValidOperations)
+	flagutil.EnableStaticFlagCompletion(cmd, "permission", aclutil.ValidPermissions)
+
+	return cmd
+}
+
+func validateFlags(opts *Options) error {
+	if _, count := opts.principal.Principal(); count != 1 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.principalRequired"))
+	}
+
+	if !flagutil.IsValidInput(opts.permission, aclutil.ValidPermissions...) {
+		return flag.InvalidValueError("permission", opts.permission, aclutil.ValidPermissions...)
+	}
+
+	for _, operation := range opts.operations {
+		if !flagutil.IsValidInput(operation, aclutil.ValidOperations...) {
+			return flag.InvalidValueError("operation", operation, aclutil.ValidOperations...)
+		}
+	}
+
+	presets := opts.producer || opts.consumer
+
+	if presets && len(opts.operations) > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.operationWithPreset"))
+	}
+
+	if !presets && len(opts.operations) == 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.operationOrPresetRequired"))
+	}
+
+	if presets && opts.topic == "" {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.topicRequired"))
+	}
+
+	if opts.consumer && opts.group == "" {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.groupRequired"))
+	}
+
+	if opts.topic == "" && opts.group == "" && opts.transactionalID == "" && !opts.cluster {
+		return errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.resourceRequired"))
+	}
+
+	return nil
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	bindings, err := newBindings(opts)
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	dump.Table(opts.IO.Out, aclutil.FromAPI(bindings))
+	logger.Info("")
+
+	if !opts.skipConfirm {
+		var confirmed bool
+		promptConfirm := &survey.Confirm{
+			Message: opts.localizer.LocalizeByID("kafka.acl.common.input.confirmCreate.message", localize.NewEntry("Count", len(bindings)), kafkaNameTmplPair),
+		}
+
+		if err = survey.AskOne(promptConfirm, &confirmed); err != nil {
+			return err
+		}
+
+		if !confirmed {
+			logger.Infoln(opts.localizer.LocalizeByID("kafka.acl.common.log.debug.createNotConfirmed"))
+			return nil
+		}
+	}
+
+	ctx := context.Background()
+	for _, binding := range bindings {
+		httpRes, createErr := api.AclsApi.CreateAcl(ctx).AclBinding(binding).Execute()
+		if createErr != nil {
+			return aclutil.APIError(opts.localizer, httpRes, createErr, kafkaInstance.GetName(), "create")
+		}
+	}
+
+	principal, _ := opts.principal.Principal()
+	logger.Info(opts.localizer.LocalizeByID("kafka.acl.common.log.info.created",
+		localize.NewEntry("Count", len(bindings)),
+		localize.NewEntry("Principal", aclutil.PrincipalName(principal)),
+		kafkaNameTmplPair,
+	))
+
+	return nil
+}
+
+// newBindings returns the bindings for each resource selected by the flags,
+// with the operations of the presets or of --operation
+func newBindings(opts *Options) ([]kafkainstanceclient.AclBinding, error) {
+	principal, _ := opts.principal.Principal()
+
+	patternType := aclutil.PatternTypeLiteral
+	if opts.prefix {
+		patternType = aclutil.PatternTypePrefix
+	}
+
+	resources := []aclutil.Resource{}
+	if opts.topic != "" {
+		resources = append(resources, aclutil.Resource{Type: aclutil.ResourceTypeTopic, Name: opts.topic, PatternType: patternType})
+	}
+	if opts.group != "" {
+		resources = append(resources, aclutil.Resource{Type: aclutil.ResourceTypeGroup, Name: opts.group, PatternType: patternType})
+	}
+	if opts.transactionalID != "" {
+		resources = append(resources, aclutil.Resource{Type: aclutil.ResourceTypeTransactionalID, Name: opts.transactionalID, PatternType: patternType})
+	}
+	if opts.cluster {
+		// the cluster is a single resource, which cannot be matched by a prefix
+		resources = append(resources, aclutil.Resource{Type: aclutil.ResourceTypeCluster, Name: aclutil.ClusterResourceName, PatternType: aclutil.PatternTypeLiteral})
+	}
+
+	bindings := []kafkainstanceclient.AclBinding{}
+	for _, resource := range resources {
+		operations := opts.operations
+		if len(operations) == 0 {
+			operations = presetOperations(opts, resource.Type)
+			if len(operations) == 0 {
+				return nil, errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.resourceNotInPreset", localize.NewEntry("ResourceType", resource.Type)))
+			}
+		}
+
+		resourceBindings, err := aclutil.NewBindings(principal, resource, operations, opts.permission)
+		if err != nil {
+			return nil, errors.New(opts.localizer.LocalizeByID("kafka.acl.grantAccess.error.invalidOperation", localize.NewEntry("ErrorMessage", err)))
+		}
+		bindings = append(bindings, resourceBindings...)
+	}
+
+	return bindings, nil
+}
+
+// presetOperations returns the operations of --producer and --consumer on a type of resource
+func presetOperations(opts *Options, resourceType string) []string {
+	seen := map[string]bool{}
+	operations := []string{}
+
+	add := func(preset string) {
+		for _, operation := range aclutil.PresetOperations(preset, resourceType) {
+			if !seen[operation] {
+				seen[operation] = true
+				operations = append(operations, operation)
+			}
+		}
+	}
+
+	if opts.producer {
+		add(aclutil.PresetProducer)
+	}
+	if opts.consumer {
+		add(aclutil.PresetConsumer)
+	}
+
+	return operations
+}
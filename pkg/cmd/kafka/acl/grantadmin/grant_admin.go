@@ -0,0 +1,127 @@
+package grantadmin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	aclutil "github.com/aerogear/charmil-host-example/pkg/kafka/acl"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	kafkaID     string
+	principal   aclutil.PrincipalFlags
+	skipConfirm bool
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewGrantAdminACLCommand gets a new command for granting a principal permission to manage the ACLs of a Kafka instance.
+func NewGrantAdminACLCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.acl.grantAdmin.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.acl.grantAdmin.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.acl.grantAdmin.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.acl.grantAdmin.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.IO.CanPrompt() && !opts.skipConfirm {
+				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			if _, count := opts.principal.Principal(); count != 1 {
+				return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.principalRequired"))
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.principal.ServiceAccount, "service-account", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.serviceAccount.description"))
+	cmd.Flags().StringVar(&opts.principal.User, "user", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.user.description"))
+	cmd.Flags().BoolVar(&opts.principal.AllAccounts, "all-accounts", false, opts.localizer.LocalizeByID("kafka.acl.common.flag.allAccounts.description"))
+	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.LocalizeByID("kafka.acl.grantAdmin.flag.yes.description"))
+
+	return cmd
+}
+
+func runCmd(opts *Options) error {
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	// altering the cluster is the permission required to manage ACLs
+	principal, _ := opts.principal.Principal()
+	cluster := aclutil.Resource{Type: aclutil.ResourceTypeCluster, Name: aclutil.ClusterResourceName, PatternType: aclutil.PatternTypeLiteral}
+	bindings, err := aclutil.NewBindings(principal, cluster, []string{aclutil.OperationAlter}, aclutil.PermissionAllow)
+	if err != nil {
+		return err
+	}
+
+	dump.Table(opts.IO.Out, aclutil.FromAPI(bindings))
+	logger.Info("")
+
+	if !opts.skipConfirm {
+		var confirmed bool
+		promptConfirm := &survey.Confirm{
+			Message: opts.localizer.LocalizeByID("kafka.acl.grantAdmin.input.confirm.message", localize.NewEntry("Principal", aclutil.PrincipalName(principal)), kafkaNameTmplPair),
+		}
+
+		if err = survey.AskOne(promptConfirm, &confirmed); err != nil {
+			return err
+		}
+
+		if !confirmed {
+			logger.Infoln(opts.localizer.LocalizeByID("kafka.acl.common.log.debug.createNotConfirmed"))
+			return nil
+		}
+	}
+
+	httpRes, err := api.AclsApi.CreateAcl(context.Background()).AclBinding(bindings[0]).Execute()
+	if err != nil {
+		return aclutil.APIError(opts.localizer, httpRes, err, kafkaInstance.GetName(), "create")
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.acl.grantAdmin.log.info.granted", localize.NewEntry("Principal", aclutil.PrincipalName(principal)), kafkaNameTmplPair))
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package list
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	aclutil "github.com/aerogear/charmil-host-example/pkg/kafka/acl"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	kafkaID      string
+	output       string
+	page         int32
+	size         int32
+	principal    aclutil.PrincipalFlags
+	resourceType string
+	resourceName string
+
+	listFlags listutil.Flags
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewListACLCommand creates a new command to list the ACL bindings of a Kafka instance
+func NewListACLCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		CfgHandler: f.CfgHandler,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.acl.list.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.acl.list.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.acl.list.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.acl.list.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.output != "" && !flagutil.IsValidInput(opts.output, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.output, flagutil.ValidOutputFormats...)
+			}
+
+			if opts.resourceType != "" && !flagutil.IsValidInput(opts.resourceType, aclutil.ValidResourceTypes...) {
+				return flag.InvalidValueError("resource-type", opts.resourceType, aclutil.ValidResourceTypes...)
+			}
+
+			if opts.page < 1 {
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.page.error.invalid.minValue", localize.NewEntry("Page", opts.page)))
+			}
+
+			if opts.size < 1 {
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.size.error.invalid.minValue", localize.NewEntry("Size", opts.size)))
+			}
+
+			if opts.listFlags.All && cmd.Flags().Changed("page") {
+				return errors.New(opts.localizer.LocalizeByID("list.error.allWithPage"))
+			}
+
+			if err := opts.listFlags.Validate(aclutil.Binding{}); err != nil {
+				return err
+			}
+
+			if _, count := opts.principal.Principal(); count > 1 {
+				return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.multiplePrincipals"))
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.acl.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runList(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", opts.localizer.LocalizeByID("kafka.acl.list.flag.output.description"))
+	cmd.Flags().Int32Var(&opts.page, "page", int32(cmdutil.DefaultPageNumber), opts.localizer.LocalizeByID("kafka.acl.list.flag.page.description"))
+	cmd.Flags().Int32Var(&opts.size, "size", int32(cmdutil.DefaultPageSize), opts.localizer.LocalizeByID("kafka.acl.list.flag.size.description"))
+	cmd.Flags().StringVar(&opts.principal.ServiceAccount, "service-account", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.serviceAccount.description"))
+	cmd.Flags().StringVar(&opts.principal.User, "user", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.user.description"))
+	cmd.Flags().BoolVar(&opts.principal.AllAccounts, "all-accounts", false, opts.localizer.LocalizeByID("kafka.acl.common.flag.allAccounts.description"))
+	cmd.Flags().StringVar(&opts.resourceType, "resource-type", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.resourceType.description"))
+	cmd.Flags().StringVar(&opts.resourceName, "resource-name", "", opts.localizer.LocalizeByID("kafka.acl.common.flag.resourceName.description"))
+	listutil.AddPaginationFlags(cmd, &opts.listFlags, opts.localizer)
+	listutil.AddTableFlags(cmd, &opts.listFlags, aclutil.Binding{}, nil, opts.localizer)
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+	flagutil.EnableStaticFlagCompletion(cmd, "resource-type", aclutil.ValidResourceTypes)
+
+	return cmd
+}
+
+func runList(opts *Options) error {
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	principal, _ := opts.principal.Principal()
+	filter := aclutil.Filter{
+		Principal:    principal,
+		ResourceType: opts.resourceType,
+		ResourceName: opts.resourceName,
+	}
+
+	ctx := context.Background()
+
+	var aclList kafkainstanceclient.AclBindingListPage
+	var httpRes *http.Response
+	if opts.listFlags.All {
+		var items []kafkainstanceclient.AclBinding
+		items, httpRes, err = aclutil.FetchAll(ctx, api, filter)
+		aclList.SetItems(items)
+		aclList.SetTotal(float32(len(items)))
+		aclList.SetPage(1)
+		aclList.SetSize(float32(len(items)))
+	} else {
+		aclList, httpRes, err = aclutil.List(ctx, api, filter, opts.page, opts.size)
+	}
+	if err != nil {
+		return aclutil.APIError(opts.localizer, httpRes, err, kafkaInstance.GetName(), "list")
+	}
+
+	if len(aclList.GetItems()) == 0 && opts.output == "" {
+		logger.Info(opts.localizer.LocalizeByID("kafka.acl.list.log.info.noACLs", localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+		return nil
+	}
+
+	bindings := aclList.GetItems()
+	rows := aclutil.FromAPI(bindings)
+	if opts.listFlags.SortBy != "" {
+		if err = listutil.Sort(rows, opts.listFlags.SortBy, bindings); err != nil {
+			return err
+		}
+		aclList.SetItems(bindings)
+	}
+
+	switch opts.output {
+	case dump.JSONFormat:
+		data, _ := json.Marshal(aclList)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(aclList)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		logger.Info("")
+		return opts.listFlags.Table(opts.IO.Out, rows)
+	}
+
+	return nil
+}
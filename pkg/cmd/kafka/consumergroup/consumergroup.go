@@ -4,7 +4,10 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup/delete"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup/describe"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup/export"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup/importoffsets"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup/list"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup/resetoffset"
 	"github.com/spf13/cobra"
 )
 
@@ -21,6 +24,9 @@ func NewConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 		list.NewListConsumerGroupCommand(f),
 		delete.NewDeleteConsumerGroupCommand(f),
 		describe.NewDescribeConsumerGroupCommand(f),
+		resetoffset.NewResetOffsetConsumerGroupCommand(f),
+		export.NewExportConsumerGroupCommand(f),
+		importoffsets.NewImportConsumerGroupCommand(f),
 	)
 
 	return cmd
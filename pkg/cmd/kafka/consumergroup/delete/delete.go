@@ -3,14 +3,19 @@ package delete
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/parallel"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	cgutil "github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
 	"github.com/aerogear/charmil/core/utils/iostreams"
 	"github.com/aerogear/charmil/core/utils/localize"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
 	"github.com/spf13/cobra"
 
 	"github.com/aerogear/charmil/core/utils/logging"
@@ -18,7 +23,8 @@ import (
 
 type Options struct {
 	kafkaID     string
-	id          string
+	ids         []string
+	empty       bool
 	skipConfirm bool
 
 	IO         *iostreams.IOStreams
@@ -28,7 +34,13 @@ type Options struct {
 	localizer  localize.Localizer
 }
 
-// NewDeleteConsumerGroupCommand gets a new command for deleting a consumer group.
+type consumerGroupRow struct {
+	ConsumerGroupID string `json:"groupId" header:"Consumer group ID"`
+	State           string `json:"state" header:"State"`
+	ActiveMembers   int    `json:"activeMembers" header:"Active members"`
+}
+
+// NewDeleteConsumerGroupCommand gets a new command for deleting consumer groups.
 func NewDeleteConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 	opts := &Options{
 		Connection: f.Connection,
@@ -45,6 +57,18 @@ func NewDeleteConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 		Example: opts.localizer.LocalizeByID("kafka.consumerGroup.delete.cmd.example"),
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if !opts.IO.CanPrompt() && !opts.skipConfirm {
+				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			if len(opts.ids) == 0 && !opts.empty {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.idOrEmptyRequired"))
+			}
+
+			if len(opts.ids) > 0 && opts.empty {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.idAndEmpty"))
+			}
+
 			if opts.kafkaID != "" {
 				return runCmd(opts)
 			}
@@ -59,10 +83,9 @@ func NewDeleteConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 		},
 	}
 
-	opts.localizer.LocalizeByID("kafka.consumerGroup.common.flag.id.description", localize.NewEntry("Action", "delete"))
 	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.LocalizeByID("kafka.consumerGroup.delete.flag.yes.description"))
-	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.consumerGroup.common.flag.id.description", localize.NewEntry("Action", "delete")))
-	_ = cmd.MarkFlagRequired("id")
+	cmd.Flags().StringSliceVar(&opts.ids, "id", []string{}, opts.localizer.LocalizeByID("kafka.consumerGroup.delete.flag.id.description"))
+	cmd.Flags().BoolVar(&opts.empty, "empty", false, opts.localizer.LocalizeByID("kafka.consumerGroup.delete.flag.empty.description"))
 
 	// flag based completions for ID
 	_ = cmd.RegisterFlagCompletionFunc("id", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -89,63 +112,166 @@ func runCmd(opts *Options) error {
 		return err
 	}
 
-	ctx := context.Background()
-
-	_, httpRes, err := api.GroupsApi.GetConsumerGroupById(ctx, opts.id).Execute()
-
-	cgIDPair := localize.NewEntry("ID", opts.id)
 	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	groups, err := resolveConsumerGroups(opts, api, kafkaInstance.GetName())
 	if err != nil {
-		if httpRes == nil {
-			return err
-		}
-		if httpRes.StatusCode == 404 {
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.notFoundError", cgIDPair, kafkaNameTmplPair))
-		}
+		return err
+	}
+
+	if len(groups) == 0 {
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.info.noEmptyGroups", kafkaNameTmplPair))
+		return nil
+	}
+
+	// several consumer groups, and those selected by --empty, are always listed, so that it is clear what is deleted
+	if len(groups) > 1 || opts.empty {
+		printConsumerGroups(opts, groups, kafkaInstance.GetName())
 	}
 
 	if !opts.skipConfirm {
-		var confirmedID string
-		promptConfirmDelete := &survey.Input{
-			Message: opts.localizer.LocalizeByID("kafka.consumerGroup.delete.input.name.message"),
+		if err = confirmDelete(opts, groups); err != nil {
+			return err
 		}
+	}
 
-		err = survey.AskOne(promptConfirmDelete, &confirmedID)
-		if err != nil {
+	// the error of a single consumer group is returned as it is
+	if len(groups) == 1 {
+		id := groups[0].GetGroupId()
+		if err = deleteConsumerGroup(opts, api, id, kafkaInstance.GetName()); err != nil {
 			return err
 		}
 
-		if confirmedID != opts.id {
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.mismatchedIDConfirmation", localize.NewEntry("ConfirmedID", confirmedID), cgIDPair))
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.info.consumerGroupDeleted", localize.NewEntry("ConsumerGroupID", id), kafkaNameTmplPair))
+		return nil
+	}
+
+	errs := parallel.Run(len(groups), parallel.DefaultConcurrency, func(i int) error {
+		return deleteConsumerGroup(opts, api, groups[i].GetGroupId(), kafkaInstance.GetName())
+	})
+
+	return reportResults(opts, logger, groups, errs, kafkaInstance.GetName())
+}
+
+// reportResults logs whether each consumer group was deleted, and returns an error counting the failures
+func reportResults(opts *Options, logger logging.Logger, groups []kafkainstanceclient.ConsumerGroup, errs []error, instanceName string) error {
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", instanceName)
+
+	for i, group := range groups {
+		cgIDPair := localize.NewEntry("ConsumerGroupID", group.GetGroupId())
+		if errs[i] != nil {
+			logger.Error(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.error.consumerGroupNotDeleted", cgIDPair, localize.NewEntry("Error", errs[i])))
+			continue
 		}
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.info.consumerGroupDeleted", cgIDPair, kafkaNameTmplPair))
+	}
+
+	if failed := parallel.CountFailed(errs); failed > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.failed", localize.NewEntry("Count", failed), localize.NewEntry("Total", len(groups))))
 	}
 
-	httpRes, err = api.GroupsApi.DeleteConsumerGroupById(ctx, opts.id).Execute()
+	logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.info.consumerGroupsDeleted", localize.NewEntry("Count", len(groups)), kafkaNameTmplPair))
 
+	return nil
+}
+
+// resolveConsumerGroups fetches the consumer groups given by ID, or all consumer groups without active members for --empty
+func resolveConsumerGroups(opts *Options, api *kafkainstanceclient.APIClient, instanceName string) ([]kafkainstanceclient.ConsumerGroup, error) {
+	ctx := context.Background()
+	groups := []kafkainstanceclient.ConsumerGroup{}
+
+	if !opts.empty {
+		seen := map[string]bool{}
+		for _, id := range opts.ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			group, httpRes, err := api.GroupsApi.GetConsumerGroupById(ctx, id).Execute()
+			if err != nil {
+				return nil, cgutil.APIError(opts.localizer, httpRes, err, id, instanceName, "view")
+			}
+			groups = append(groups, group)
+		}
+		return groups, nil
+	}
+
+	all, httpRes, err := cgutil.FetchAllForTopic(ctx, api, "")
 	if err != nil {
-		if httpRes == nil {
-			return err
+		return nil, cgutil.APIError(opts.localizer, httpRes, err, "", instanceName, "list")
+	}
+
+	for _, group := range all {
+		if cgutil.IsEmpty(group) {
+			groups = append(groups, group)
 		}
+	}
+
+	return groups, nil
+}
+
+func deleteConsumerGroup(opts *Options, api *kafkainstanceclient.APIClient, id string, instanceName string) error {
+	httpRes, err := api.GroupsApi.DeleteConsumerGroupById(context.Background(), id).Execute()
+	if err == nil {
+		return nil
+	}
+
+	if httpRes != nil && httpRes.StatusCode == 423 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.locked"))
+	}
+
+	return cgutil.APIError(opts.localizer, httpRes, err, id, instanceName, "delete")
+}
 
-		operationTmplPair := localize.NewEntry("Operation", "delete")
-
-		switch httpRes.StatusCode {
-		case 401:
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.unauthorized", operationTmplPair))
-		case 403:
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.forbidden", operationTmplPair))
-		case 423:
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.locked"))
-		case 500:
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.internalServerError"))
-		case 503:
-			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName())))
-		default:
+// printConsumerGroups lists the consumer groups to delete with their state on the error stream
+func printConsumerGroups(opts *Options, groups []kafkainstanceclient.ConsumerGroup, instanceName string) {
+	rows := make([]consumerGroupRow, len(groups))
+	for i, group := range groups {
+		rows[i] = consumerGroupRow{
+			ConsumerGroupID: group.GetGroupId(),
+			State:           group.GetState(),
+			ActiveMembers:   cgutil.GetActiveConsumersCount(cgutil.FilterConsumersWithMember(group.GetConsumers())),
+		}
+	}
+
+	fmt.Fprintln(opts.IO.ErrOut, opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.info.consumerGroupsToDelete", localize.NewEntry("Count", len(groups)), localize.NewEntry("InstanceName", instanceName)))
+	dump.Table(opts.IO.ErrOut, rows)
+	fmt.Fprintln(opts.IO.ErrOut)
+}
+
+// confirmDelete asks to type the ID of the consumer group, or a confirmation phrase when deleting several consumer groups
+func confirmDelete(opts *Options, groups []kafkainstanceclient.ConsumerGroup) error {
+	if len(groups) == 1 {
+		id := groups[0].GetGroupId()
+		promptConfirmDelete := &survey.Input{
+			Message: opts.localizer.LocalizeByID("kafka.consumerGroup.delete.input.name.message"),
+		}
+
+		var confirmedID string
+		if err := survey.AskOne(promptConfirmDelete, &confirmedID); err != nil {
 			return err
 		}
+
+		if confirmedID != id {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.mismatchedIDConfirmation", localize.NewEntry("ConfirmedID", confirmedID), localize.NewEntry("ID", id)))
+		}
+		return nil
+	}
+
+	phrase := opts.localizer.LocalizeByID("kafka.consumerGroup.delete.input.phrase.value", localize.NewEntry("Count", len(groups)))
+	promptConfirmPhrase := &survey.Input{
+		Message: opts.localizer.LocalizeByID("kafka.consumerGroup.delete.input.phrase.message", localize.NewEntry("Phrase", phrase)),
 	}
 
-	logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.log.info.consumerGroupDeleted", localize.NewEntry("ConsumerGroupID", opts.id), kafkaNameTmplPair))
+	var confirmedPhrase string
+	if err := survey.AskOne(promptConfirmPhrase, &confirmedPhrase); err != nil {
+		return err
+	}
+
+	if confirmedPhrase != phrase {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.delete.error.mismatchedPhraseConfirmation", localize.NewEntry("Phrase", phrase)))
+	}
 
 	return nil
 }
@@ -0,0 +1,264 @@
+package delete
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	pkgAPI "github.com/aerogear/charmil-host-example/pkg/api"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/localesettings"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"golang.org/x/text/language"
+)
+
+func newTestLocalizer(t *testing.T) localize.Localizer {
+	localizer, err := localize.New(&localize.Config{
+		Language: &language.English,
+		Files:    localesettings.DefaultLocales,
+		Format:   "toml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return localizer
+}
+
+// newGroupsServer serves the consumer groups, listed or looked up by ID, and records the IDs which were looked up
+func newGroupsServer(groups []kafkainstanceclient.ConsumerGroup) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	requested := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if id := strings.TrimPrefix(r.URL.Path, "/consumer-groups/"); id != r.URL.Path {
+			mu.Lock()
+			requested = append(requested, id)
+			mu.Unlock()
+
+			for _, g := range groups {
+				if g.GetGroupId() == id {
+					_ = json.NewEncoder(w).Encode(g)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+			return
+		}
+
+		list := kafkainstanceclient.ConsumerGroupList{}
+		list.SetItems(groups)
+		list.SetTotal(float32(len(groups)))
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+
+	return server, &requested
+}
+
+func TestResolveConsumerGroups(t *testing.T) {
+	localizer := newTestLocalizer(t)
+
+	groups := []kafkainstanceclient.ConsumerGroup{
+		mockutil.NewConsumerGroupMock("active", mockutil.NewConsumerMock("orders", 0, "member-1", 0)),
+		mockutil.NewConsumerGroupMock("empty-1", mockutil.NewConsumerMock("orders", 0, "", 0)),
+		mockutil.NewConsumerGroupMock("empty-2", mockutil.NewConsumerMock("orders", 0, "", 0)),
+	}
+
+	tests := []struct {
+		name          string
+		ids           []string
+		empty         bool
+		wantIDs       []string
+		wantRequested []string
+		wantErr       bool
+	}{
+		{
+			name:          "Should fetch the consumer groups given by ID",
+			ids:           []string{"active", "empty-1"},
+			wantIDs:       []string{"active", "empty-1"},
+			wantRequested: []string{"active", "empty-1"},
+		},
+		{
+			name:          "Should fetch each consumer group given more than once only once",
+			ids:           []string{"empty-1", "active", "empty-1"},
+			wantIDs:       []string{"empty-1", "active"},
+			wantRequested: []string{"empty-1", "active"},
+		},
+		{
+			name:          "Should select the consumer groups without active members for --empty",
+			empty:         true,
+			wantIDs:       []string{"empty-1", "empty-2"},
+			wantRequested: []string{},
+		},
+		{
+			name:          "Should return an error when a consumer group does not exist",
+			ids:           []string{"active", "missing"},
+			wantRequested: []string{"active", "missing"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			server, requested := newGroupsServer(groups)
+			defer server.Close()
+
+			cfg := kafkainstanceclient.NewConfiguration()
+			cfg.Servers = kafkainstanceclient.ServerConfigurations{{URL: server.URL}}
+			api := kafkainstanceclient.NewAPIClient(cfg)
+
+			opts := &Options{ids: tt.ids, empty: tt.empty, localizer: localizer}
+
+			got, err := resolveConsumerGroups(opts, api, "my-kafka")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveConsumerGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(*requested, tt.wantRequested) {
+				t.Errorf("resolveConsumerGroups() requested %v, want %v", *requested, tt.wantRequested)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			ids := []string{}
+			for _, g := range got {
+				ids = append(ids, g.GetGroupId())
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("resolveConsumerGroups() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestReportResults(t *testing.T) {
+	localizer := newTestLocalizer(t)
+
+	groups := []kafkainstanceclient.ConsumerGroup{
+		mockutil.NewConsumerGroupMock("group-1", mockutil.NewConsumerMock("orders", 0, "", 0)),
+		mockutil.NewConsumerGroupMock("group-2", mockutil.NewConsumerMock("orders", 0, "", 0)),
+		mockutil.NewConsumerGroupMock("group-3", mockutil.NewConsumerMock("orders", 0, "", 0)),
+	}
+
+	tests := []struct {
+		name       string
+		errs       []error
+		wantErr    string
+		wantOut    []string
+		wantErrOut []string
+	}{
+		{
+			name:    "Should report every consumer group as deleted",
+			errs:    []error{nil, nil, nil},
+			wantOut: []string{`"group-1" has been deleted`, `"group-2" has been deleted`, `"group-3" has been deleted`, "3 consumer groups have been deleted"},
+		},
+		{
+			name:       "Should report the error of each consumer group which was not deleted",
+			errs:       []error{nil, errors.New("locked"), errors.New("forbidden")},
+			wantErr:    "2 of 3 consumer groups could not be deleted",
+			wantOut:    []string{`"group-1" has been deleted`},
+			wantErrOut: []string{`"group-2" could not be deleted: locked`, `"group-3" could not be deleted: forbidden`},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+			logger, err := logging.NewStdLoggerBuilder().Streams(out, errOut).Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := &Options{localizer: localizer}
+
+			err = reportResults(opts, logger, groups, tt.errs, "my-kafka")
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("reportResults() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("reportResults() error = %v, want %q", err, tt.wantErr)
+			}
+
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("reportResults() output %q does not contain %q", out.String(), want)
+				}
+			}
+			for _, want := range tt.wantErrOut {
+				if !strings.Contains(errOut.String(), want) {
+					t.Errorf("reportResults() error output %q does not contain %q", errOut.String(), want)
+				}
+			}
+			if tt.wantErr != "" && strings.Contains(out.String(), "consumer groups have been deleted") {
+				t.Errorf("reportResults() reported all consumer groups as deleted: %q", out.String())
+			}
+		})
+	}
+}
+
+func TestRunCmdListsSingleEmptyGroup(t *testing.T) {
+	groups := []kafkainstanceclient.ConsumerGroup{
+		mockutil.NewConsumerGroupMock("active", mockutil.NewConsumerMock("orders", 0, "member-1", 0)),
+		mockutil.NewConsumerGroupMock("empty-1", mockutil.NewConsumerMock("orders", 0, "", 0)),
+	}
+
+	server, _ := newGroupsServer(groups)
+	defer server.Close()
+
+	cfg := kafkainstanceclient.NewConfiguration()
+	cfg.Servers = kafkainstanceclient.ServerConfigurations{{URL: server.URL}}
+	api := kafkainstanceclient.NewAPIClient(cfg)
+	kafkaInstance := mockutil.NewKafkaRequestTypeMock("my-kafka")
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	logger, err := logging.NewStdLoggerBuilder().Streams(out, errOut).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &Options{
+		kafkaID:     kafkaInstance.GetId(),
+		empty:       true,
+		skipConfirm: true,
+		IO:          &iostreams.IOStreams{In: ioutil.NopCloser(&bytes.Buffer{}), Out: out, ErrOut: errOut},
+		Connection: func(*connection.Config) (connection.Connection, error) {
+			return &connection.ConnectionMock{
+				APIFunc: func() *pkgAPI.API {
+					return &pkgAPI.API{
+						KafkaAdmin: func(string) (*kafkainstanceclient.APIClient, *kafkamgmtclient.KafkaRequest, error) {
+							return api, &kafkaInstance, nil
+						},
+					}
+				},
+			}, nil
+		},
+		Logger:    func() (logging.Logger, error) { return logger, nil },
+		localizer: newTestLocalizer(t),
+	}
+
+	if err = runCmd(opts); err != nil {
+		t.Fatalf("runCmd() error = %v", err)
+	}
+
+	// the only group selected by --empty is listed before it is deleted
+	if !strings.Contains(errOut.String(), "empty-1") {
+		t.Errorf("runCmd() did not list the consumer group to delete, error output: %q", errOut.String())
+	}
+	if strings.Contains(errOut.String(), "active") {
+		t.Errorf("runCmd() listed a consumer group with active members, error output: %q", errOut.String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	cgutil "github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
@@ -30,6 +31,9 @@ type Options struct {
 	kafkaID      string
 	outputFormat string
 	id           string
+	view         string
+	all          bool
+	thresholds   cgutil.LagThresholds
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -37,6 +41,21 @@ type Options struct {
 	localizer  localize.Localizer
 }
 
+// views of the consumers of a consumer group
+const (
+	viewPartitions = "partitions"
+	viewTopics     = "topics"
+	viewMembers    = "members"
+)
+
+var validViews = []string{viewPartitions, viewTopics, viewMembers}
+
+type memberRow struct {
+	MemberID   string `header:"Member ID"`
+	Partitions string `header:"Partitions"`
+	TotalLag   int    `header:"Total lag"`
+}
+
 type consumerRow struct {
 	MemberID      string `json:"memberId,omitempty" header:"Member ID"`
 	Partition     int    `json:"partition,omitempty" header:"Partition"`
@@ -67,6 +86,14 @@ func NewDescribeConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 				}
 			}
 
+			if !flagutil.IsValidInput(opts.view, validViews...) {
+				return flag.InvalidValueError("view", opts.view, validViews...)
+			}
+
+			if err = validateThresholdFlags(opts); err != nil {
+				return err
+			}
+
 			if opts.kafkaID != "" {
 				return runCmd(opts)
 			}
@@ -83,7 +110,10 @@ func NewDescribeConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.consumerGroup.common.flag.output.description"))
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.consumerGroup.common.flag.id.description", localize.NewEntry("Action", "view")))
-	_ = cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVar(&opts.view, "view", viewPartitions, opts.localizer.LocalizeByID("kafka.consumerGroup.describe.flag.view.description"))
+	cmd.Flags().BoolVar(&opts.all, "all", false, opts.localizer.LocalizeByID("kafka.consumerGroup.describe.flag.all.description"))
+	cmd.Flags().IntVar(&opts.thresholds.MaxLag, cgutil.ThresholdMaxLag, cgutil.NoThreshold, opts.localizer.LocalizeByID("kafka.consumerGroup.describe.flag.maxLag.description"))
+	cmd.Flags().IntVar(&opts.thresholds.MaxPartitionsWithLag, cgutil.ThresholdMaxPartitionsWithLag, cgutil.NoThreshold, opts.localizer.LocalizeByID("kafka.consumerGroup.describe.flag.maxPartitionsWithLag.description"))
 
 	// flag based completions for ID
 	_ = cmd.RegisterFlagCompletionFunc("id", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -91,10 +121,38 @@ func NewDescribeConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 	})
 
 	flagutil.EnableOutputFlagCompletion(cmd)
+	flagutil.EnableStaticFlagCompletion(cmd, "view", validViews)
 
 	return cmd
 }
 
+// validateThresholdFlags checks that either a single consumer group or all of them are described,
+// and that all consumer groups are only checked against lag thresholds
+func validateThresholdFlags(opts *Options) error {
+	if opts.id == "" && !opts.all {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.describe.error.idOrAllRequired"))
+	}
+
+	if opts.id != "" && opts.all {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.describe.error.idAndAll"))
+	}
+
+	if opts.thresholds.MaxLag < cgutil.NoThreshold {
+		return flag.InvalidValueError(cgutil.ThresholdMaxLag, opts.thresholds.MaxLag)
+	}
+
+	if opts.thresholds.MaxPartitionsWithLag < cgutil.NoThreshold {
+		return flag.InvalidValueError(cgutil.ThresholdMaxPartitionsWithLag, opts.thresholds.MaxPartitionsWithLag)
+	}
+
+	if opts.all && !opts.thresholds.IsSet() {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.describe.error.allRequiresThreshold"))
+	}
+
+	return nil
+}
+
+// nolint:funlen
 func runCmd(opts *Options) error {
 	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
 	if err != nil {
@@ -108,6 +166,22 @@ func runCmd(opts *Options) error {
 
 	ctx := context.Background()
 
+	if opts.all {
+		groups, httpRes, fetchErr := cgutil.FetchAllForTopic(ctx, api, "")
+		if fetchErr != nil {
+			return cgutil.APIError(opts.localizer, httpRes, fetchErr, "", kafkaInstance.GetName(), "list")
+		}
+
+		violations := []cgutil.LagViolation{}
+		for _, group := range groups {
+			violations = append(violations, opts.thresholds.Check(group)...)
+		}
+
+		printLagViolations(opts.IO.Out, violations, opts.outputFormat)
+
+		return lagThresholdError(opts, violations, len(groups))
+	}
+
 	consumerGroupData, httpRes, err := api.GroupsApi.GetConsumerGroupById(ctx, opts.id).Execute()
 	if err != nil {
 		if httpRes == nil {
@@ -135,18 +209,81 @@ func runCmd(opts *Options) error {
 	}
 
 	stdout := opts.IO.Out
+	var output interface{} = consumerGroupData
+	switch opts.view {
+	case viewTopics:
+		output = cgutil.SummarizeByTopic(consumerGroupData.GetConsumers())
+	case viewMembers:
+		output = cgutil.SummarizeByMember(consumerGroupData.GetConsumers())
+	}
+
 	switch opts.outputFormat {
 	case dump.JSONFormat:
-		data, _ := json.Marshal(consumerGroupData)
+		data, _ := json.Marshal(output)
 		_ = dump.JSON(stdout, data)
 	case dump.YAMLFormat, dump.YMLFormat:
-		data, _ := yaml.Marshal(consumerGroupData)
+		data, _ := yaml.Marshal(output)
 		_ = dump.YAML(stdout, data)
 	default:
-		printConsumerGroupDetails(stdout, consumerGroupData, opts.localizer)
+		printConsumerGroupDetails(stdout, consumerGroupData, opts.view, opts.localizer)
 	}
 
-	return nil
+	if !opts.thresholds.IsSet() {
+		return nil
+	}
+
+	violations := opts.thresholds.Check(consumerGroupData)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	// keep structured output on stdout parseable
+	if opts.outputFormat == "" {
+		fmt.Fprintln(stdout, "")
+		fmt.Fprintln(stdout, color.Bold(opts.localizer.LocalizeByID("kafka.consumerGroup.describe.output.lagThresholdExceeded")))
+		printLagViolations(stdout, violations, "")
+	} else {
+		printLagViolations(opts.IO.ErrOut, violations, "")
+	}
+
+	return lagThresholdError(opts, violations, 1)
+}
+
+// printLagViolations prints the partitions which broke a lag threshold
+func printLagViolations(w io.Writer, violations []cgutil.LagViolation, outputFormat string) {
+	switch outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.Marshal(violations)
+		_ = dump.JSON(w, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(violations)
+		_ = dump.YAML(w, data)
+	default:
+		if len(violations) > 0 {
+			dump.Table(w, violations)
+		}
+	}
+}
+
+// lagThresholdError returns an error with the exit code of the broken thresholds, or nil when there are none
+func lagThresholdError(opts *Options, violations []cgutil.LagViolation, groupCount int) error {
+	code := cgutil.LagExitCode(violations)
+	if code == 0 {
+		return nil
+	}
+
+	groupIDs := map[string]bool{}
+	for _, v := range violations {
+		groupIDs[v.ConsumerGroupID] = true
+	}
+
+	return &cmdutil.ExitError{
+		Code: code,
+		Err: errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.describe.error.lagThresholdExceeded",
+			localize.NewEntry("Count", len(groupIDs)),
+			localize.NewEntry("Total", groupCount),
+		)),
+	}
 }
 
 func mapConsumerGroupDescribeToTableFormat(consumers []kafkainstanceclient.Consumer) []consumerRow {
@@ -178,8 +315,32 @@ func mapConsumerGroupDescribeToTableFormat(consumers []kafkainstanceclient.Consu
 	return rows
 }
 
+// mapMembersToTableFormat lists the partitions assigned to each member of a consumer group
+func mapMembersToTableFormat(members []cgutil.MemberSummary) []memberRow {
+	rows := make([]memberRow, len(members))
+
+	for i, member := range members {
+		partitions := make([]string, len(member.Partitions))
+		for j, p := range member.Partitions {
+			partitions[j] = fmt.Sprintf("%v-%v", p.Topic, p.Partition)
+		}
+
+		rows[i] = memberRow{
+			MemberID:   member.MemberID,
+			Partitions: strings.Join(partitions, ", "),
+			TotalLag:   member.TotalLag,
+		}
+
+		if member.MemberID == "" {
+			rows[i].MemberID = color.Bold("unconsumed")
+		}
+	}
+
+	return rows
+}
+
 // print the consumer grooup details
-func printConsumerGroupDetails(w io.Writer, consumerGroupData kafkainstanceclient.ConsumerGroup, localizer localize.Localizer) {
+func printConsumerGroupDetails(w io.Writer, consumerGroupData kafkainstanceclient.ConsumerGroup, view string, localizer localize.Localizer) {
 	fmt.Fprintln(w, "")
 	consumers := consumerGroupData.GetConsumers()
 
@@ -190,6 +351,13 @@ func printConsumerGroupDetails(w io.Writer, consumerGroupData kafkainstanceclien
 	fmt.Fprintln(w, color.Bold(localizer.LocalizeByID("kafka.consumerGroup.describe.output.activeMembers")), activeMembersCount, "\t", color.Bold(localizer.LocalizeByID("kafka.consumerGroup.describe.output.partitionsWithLag")), partitionsWithLagCount, "\t", color.Bold(localizer.LocalizeByID("kafka.consumerGroup.describe.output.unconsumedPartitions")), unconsumedPartitions)
 	fmt.Fprintln(w, "")
 
-	rows := mapConsumerGroupDescribeToTableFormat(consumers)
-	dump.Table(w, rows)
+	switch view {
+	case viewTopics:
+		dump.Table(w, cgutil.SummarizeByTopic(consumers))
+	case viewMembers:
+		dump.Table(w, mapMembersToTableFormat(cgutil.SummarizeByMember(consumers)))
+	default:
+		rows := mapConsumerGroupDescribeToTableFormat(consumers)
+		dump.Table(w, rows)
+	}
 }
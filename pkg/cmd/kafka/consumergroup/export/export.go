@@ -0,0 +1,197 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sort"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	cgutil "github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	kafkaID         string
+	outputFormat    string
+	file            string
+	ids             []string
+	topic           string
+	credentialsFile string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewExportConsumerGroupCommand gets a new command for exporting the committed offsets of consumer groups.
+func NewExportConsumerGroupCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.consumerGroup.export.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.consumerGroup.export.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.consumerGroup.export.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.consumerGroup.export.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err = flag.ValidateOutput(opts.outputFormat); err != nil {
+				return err
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", dump.YAMLFormat, opts.localizer.LocalizeByID("kafka.consumerGroup.export.flag.output.description"))
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", opts.localizer.LocalizeByID("kafka.consumerGroup.export.flag.file.description"))
+	cmd.Flags().StringSliceVar(&opts.ids, "id", []string{}, opts.localizer.LocalizeByID("kafka.consumerGroup.export.flag.id.description"))
+	cmd.Flags().StringVar(&opts.topic, "topic", "", opts.localizer.LocalizeByID("kafka.consumerGroup.export.flag.topic.description"))
+	cmd.Flags().StringVar(&opts.credentialsFile, "credentials-file", "", opts.localizer.LocalizeByID("kafka.consumerGroup.export.flag.credentialsFile.description"))
+
+	_ = cmd.RegisterFlagCompletionFunc("id", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidConsumerGroupIDs(f, toComplete)
+	})
+
+	_ = cmd.RegisterFlagCompletionFunc("topic", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidTopicNameArgs(f, toComplete)
+	})
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	var groups []kafkainstanceclient.ConsumerGroup
+	if len(opts.ids) == 0 {
+		fetched, res, fetchErr := cgutil.FetchAllForTopic(ctx, api, opts.topic)
+		if fetchErr != nil {
+			return cgutil.APIError(opts.localizer, res, fetchErr, "", kafkaInstance.GetName(), "list")
+		}
+		groups = fetched
+	} else {
+		for _, id := range opts.ids {
+			group, res, getErr := api.GroupsApi.GetConsumerGroupById(ctx, id).Execute()
+			if getErr != nil {
+				return cgutil.APIError(opts.localizer, res, getErr, id, kafkaInstance.GetName(), "view")
+			}
+			groups = append(groups, group)
+		}
+	}
+
+	tokens, err := messaging.NewTokenSource(opts.CfgHandler.Cfg, opts.credentialsFile)
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.export.error.invalidCredentialsFile", localize.NewEntry("File", opts.credentialsFile), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	cfg, err := messaging.ConfigForKafka(kafkaInstance, tokens, opts.CfgHandler.Cfg.Insecure)
+	if err != nil {
+		return err
+	}
+
+	client := messaging.NewClient(cfg)
+	defer client.Close()
+
+	// the API returns offsets as 32-bit floats, so the exact offsets are read with the Kafka protocol
+	export := cgutil.OffsetExport{Instance: kafkaInstance.GetName(), Groups: []cgutil.GroupOffsets{}}
+	for _, group := range groups {
+		committed, fetchErr := client.CommittedOffsets(ctx, group.GetGroupId(), cgutil.GetTopics(group.GetConsumers()))
+		if fetchErr != nil {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.export.error.committedOffsets", localize.NewEntry("ID", group.GetGroupId()), localize.NewEntry("ErrorMessage", fetchErr)))
+		}
+
+		offsets := cgutil.ExportOffsets(group.GetGroupId(), committed)
+		if opts.topic != "" {
+			offsets.Offsets = filterOffsetsByTopic(offsets.Offsets, opts.topic)
+		}
+		export.Groups = append(export.Groups, offsets)
+	}
+
+	sort.Slice(export.Groups, func(i, j int) bool {
+		return export.Groups[i].ID < export.Groups[j].ID
+	})
+
+	var data []byte
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, err = json.MarshalIndent(export, "", "  ")
+	default:
+		data, err = yaml.Marshal(export)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.file == "" {
+		if opts.outputFormat == dump.JSONFormat {
+			_ = dump.JSON(opts.IO.Out, data)
+		} else {
+			_ = dump.YAML(opts.IO.Out, data)
+		}
+	} else if err = ioutil.WriteFile(opts.file, data, 0600); err != nil {
+		return err
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.export.log.info.exported",
+		localize.NewEntry("Count", len(export.Groups)),
+		localize.NewEntry("InstanceName", kafkaInstance.GetName()),
+	))
+
+	return nil
+}
+
+func filterOffsetsByTopic(offsets []cgutil.CommittedOffset, topic string) []cgutil.CommittedOffset {
+	filtered := []cgutil.CommittedOffset{}
+	for _, o := range offsets {
+		if o.Topic == topic {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered
+}
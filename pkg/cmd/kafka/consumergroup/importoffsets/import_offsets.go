@@ -0,0 +1,277 @@
+package importoffsets
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	cgutil "github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	kafkaID         string
+	file            string
+	ids             []string
+	topicMappings   []string
+	credentialsFile string
+	dryRun          bool
+	skipConfirm     bool
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+type offsetRow struct {
+	ConsumerGroupID string `header:"Consumer group ID"`
+	Topic           string `header:"Topic"`
+	Partition       int    `header:"Partition"`
+	Offset          int64  `header:"Offset"`
+}
+
+// NewImportConsumerGroupCommand gets a new command for importing the committed offsets of consumer groups.
+func NewImportConsumerGroupCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.consumerGroup.import.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.consumerGroup.import.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.consumerGroup.import.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.consumerGroup.import.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.IO.CanPrompt() && !opts.skipConfirm && !opts.dryRun {
+				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", opts.localizer.LocalizeByID("kafka.consumerGroup.import.flag.file.description"))
+	cmd.Flags().StringSliceVar(&opts.ids, "id", []string{}, opts.localizer.LocalizeByID("kafka.consumerGroup.import.flag.id.description"))
+	cmd.Flags().StringArrayVar(&opts.topicMappings, "topic-mapping", []string{}, opts.localizer.LocalizeByID("kafka.consumerGroup.import.flag.topicMapping.description"))
+	cmd.Flags().StringVar(&opts.credentialsFile, "credentials-file", "", opts.localizer.LocalizeByID("kafka.consumerGroup.import.flag.credentialsFile.description"))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, opts.localizer.LocalizeByID("kafka.consumerGroup.import.flag.dryRun.description"))
+	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.LocalizeByID("kafka.consumerGroup.import.flag.yes.description"))
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	export, err := readExport(opts)
+	if err != nil {
+		return err
+	}
+
+	mapping, err := cgutil.ParseTopicMapping(opts.topicMappings)
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.import.error.invalidTopicMapping", localize.NewEntry("ErrorMessage", err)))
+	}
+	export.MapTopics(mapping)
+
+	if len(opts.ids) > 0 {
+		export.Groups = filterGroups(export.Groups, opts.ids)
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	if len(export.Topics()) == 0 {
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.import.log.info.noOffsets", localize.NewEntry("File", opts.file)))
+		return nil
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	// offsets can only be committed for groups without active members
+	for _, g := range export.Groups {
+		group, httpRes, getErr := api.GroupsApi.GetConsumerGroupById(ctx, g.ID).Execute()
+		if getErr != nil {
+			// the group is created in the target instance when its offsets are reset
+			if httpRes != nil && httpRes.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return cgutil.APIError(opts.localizer, httpRes, getErr, g.ID, kafkaInstance.GetName(), "view")
+		}
+
+		if !cgutil.IsEmpty(group) {
+			active := cgutil.GetActiveConsumersCount(cgutil.FilterConsumersWithMember(group.GetConsumers()))
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.import.error.activeMembers", localize.NewEntry("ID", g.ID), localize.NewEntry("Count", active)))
+		}
+	}
+
+	outOfRange, err := checkOffsetRanges(opts, kafkaInstance, export)
+	if err != nil {
+		return err
+	}
+	if len(outOfRange) > 0 {
+		dump.Table(opts.IO.Out, outOfRange)
+		logger.Info("")
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.import.error.offsetsOutOfRange", localize.NewEntry("Count", len(outOfRange)), kafkaNameTmplPair))
+	}
+
+	rows := []offsetRow{}
+	for _, g := range export.Groups {
+		for _, o := range g.Offsets {
+			rows = append(rows, offsetRow{ConsumerGroupID: g.ID, Topic: o.Topic, Partition: o.Partition, Offset: o.Offset})
+		}
+	}
+	dump.Table(opts.IO.Out, rows)
+	logger.Info("")
+
+	if opts.dryRun {
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.import.log.info.dryRun"))
+		return nil
+	}
+
+	if !opts.skipConfirm {
+		var confirmed bool
+		promptConfirm := &survey.Confirm{
+			Message: opts.localizer.LocalizeByID("kafka.consumerGroup.import.input.confirm.message", localize.NewEntry("Count", len(export.Groups)), kafkaNameTmplPair),
+		}
+
+		if err = survey.AskOne(promptConfirm, &confirmed); err != nil {
+			return err
+		}
+
+		if !confirmed {
+			logger.Infoln(opts.localizer.LocalizeByID("kafka.consumerGroup.import.log.debug.cancelled"))
+			return nil
+		}
+	}
+
+	for _, g := range export.Groups {
+		for _, params := range cgutil.OffsetResetParameters(g) {
+			_, httpRes, resetErr := api.GroupsApi.ResetConsumerGroupOffset(ctx, g.ID).ConsumerGroupResetOffsetParameters(params).Execute()
+			if resetErr != nil {
+				return cgutil.APIError(opts.localizer, httpRes, resetErr, g.ID, kafkaInstance.GetName(), "reset")
+			}
+		}
+
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.import.log.info.groupImported", localize.NewEntry("ID", g.ID), localize.NewEntry("Count", len(g.Offsets))))
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.import.log.info.imported", localize.NewEntry("Count", len(export.Groups)), kafkaNameTmplPair))
+
+	return nil
+}
+
+// readExport reads the exported offsets from --file, or from standard input when it is "-"
+func readExport(opts *Options) (*cgutil.OffsetExport, error) {
+	var r io.Reader
+	if opts.file == "-" {
+		r = opts.IO.In
+	} else {
+		f, err := os.Open(opts.file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	export, err := cgutil.ReadOffsetExport(r)
+	if err != nil {
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.import.error.invalidFile", localize.NewEntry("File", opts.file), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	return export, nil
+}
+
+// checkOffsetRanges reads the range of offsets of each partition in the target instance
+// and returns the offsets which are outside of them
+func checkOffsetRanges(opts *Options, kafkaInstance *kafkamgmtclient.KafkaRequest, export *cgutil.OffsetExport) ([]cgutil.OffsetOutOfRange, error) {
+	tokens, err := messaging.NewTokenSource(opts.CfgHandler.Cfg, opts.credentialsFile)
+	if err != nil {
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.import.error.invalidCredentialsFile", localize.NewEntry("File", opts.credentialsFile), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	cfg, err := messaging.ConfigForKafka(kafkaInstance, tokens, opts.CfgHandler.Cfg.Insecure)
+	if err != nil {
+		return nil, err
+	}
+
+	client := messaging.NewClient(cfg)
+	defer client.Close()
+
+	ctx := context.Background()
+
+	// topics missing from the target instance have no ranges, so all of their offsets are out of range
+	ranges := map[string]map[int]messaging.OffsetRange{}
+	for _, topic := range export.Topics() {
+		topicRanges, rangeErr := client.OffsetRanges(ctx, topic)
+		if messaging.IsUnknownTopic(rangeErr) {
+			continue
+		}
+		if rangeErr != nil {
+			return nil, errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.import.error.offsetRanges", localize.NewEntry("TopicName", topic), localize.NewEntry("ErrorMessage", rangeErr)))
+		}
+		ranges[topic] = topicRanges
+	}
+
+	outOfRange := []cgutil.OffsetOutOfRange{}
+	for _, g := range export.Groups {
+		outOfRange = append(outOfRange, cgutil.CheckOffsetRanges(g, ranges)...)
+	}
+
+	return outOfRange, nil
+}
+
+func filterGroups(groups []cgutil.GroupOffsets, ids []string) []cgutil.GroupOffsets {
+	selected := map[string]bool{}
+	for _, id := range ids {
+		selected[id] = true
+	}
+
+	filtered := []cgutil.GroupOffsets{}
+	for _, g := range groups {
+		if selected[g.ID] {
+			filtered = append(filtered, g)
+		}
+	}
+	return filtered
+}
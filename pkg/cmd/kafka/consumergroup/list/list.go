@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 
 	"gopkg.in/yaml.v2"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 
 	"github.com/aerogear/charmil-host-example/pkg/connection"
@@ -37,12 +39,17 @@ type Options struct {
 	search  string
 	page    int32
 	size    int32
+	lagging bool
+	empty   bool
+
+	listFlags listutil.Flags
 }
 
 type consumerGroupRow struct {
-	ConsumerGroupID   string `json:"groupId,omitempty" header:"Consumer group ID"`
-	ActiveMembers     int    `json:"active_members,omitempty" header:"Active members"`
-	PartitionsWithLag int    `json:"lag,omitempty" header:"Partitions with lag"`
+	ConsumerGroupID   string `json:"id,omitempty" header:"Consumer group ID"`
+	ActiveMembers     int    `json:"members,omitempty" header:"Active members" sort:"desc"`
+	PartitionsWithLag int    `json:"partitionsWithLag,omitempty" header:"Partitions with lag" sort:"desc"`
+	TotalLag          int    `json:"lag,omitempty" header:"Total lag" sort:"desc"`
 }
 
 // NewListConsumerGroupCommand creates a new command to list consumer groups
@@ -74,6 +81,18 @@ func NewListConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.size.error.invalid.minValue", localize.NewEntry("Size", opts.size)))
 			}
 
+			if opts.listFlags.All && cmd.Flags().Changed("page") {
+				return errors.New(opts.localizer.LocalizeByID("list.error.allWithPage"))
+			}
+
+			if (opts.lagging || opts.empty) && cmd.Flags().Changed("page") {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.list.error.filterWithPage"))
+			}
+
+			if err := opts.listFlags.Validate(consumerGroupRow{}); err != nil {
+				return err
+			}
+
 			if !f.CfgHandler.Cfg.HasKafka() {
 				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.noKafkaSelected"))
 			}
@@ -89,6 +108,10 @@ func NewListConsumerGroupCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.search, "search", "", opts.localizer.LocalizeByID("kafka.consumerGroup.list.flag.search"))
 	cmd.Flags().Int32VarP(&opts.page, "page", "", int32(cmdutil.DefaultPageNumber), opts.localizer.LocalizeByID("kafka.consumerGroup.list.flag.page"))
 	cmd.Flags().Int32VarP(&opts.size, "size", "", int32(cmdutil.DefaultPageSize), opts.localizer.LocalizeByID("kafka.consumerGroup.list.flag.size"))
+	cmd.Flags().BoolVar(&opts.lagging, "lagging", false, opts.localizer.LocalizeByID("kafka.consumerGroup.list.flag.lagging"))
+	cmd.Flags().BoolVar(&opts.empty, "empty", false, opts.localizer.LocalizeByID("kafka.consumerGroup.list.flag.empty"))
+	listutil.AddPaginationFlags(cmd, &opts.listFlags, opts.localizer)
+	listutil.AddTableFlags(cmd, &opts.listFlags, consumerGroupRow{}, nil, opts.localizer)
 
 	_ = cmd.RegisterFlagCompletionFunc("topic", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return cmdutil.FilterValidTopicNameArgs(f, toComplete)
@@ -118,20 +141,45 @@ func runList(opts *Options) (err error) {
 		return err
 	}
 
-	req := api.GroupsApi.GetConsumerGroups(ctx)
+	fetchPage := func(page int32) (kafkainstanceclient.ConsumerGroupList, *http.Response, error) {
+		req := api.GroupsApi.GetConsumerGroups(ctx)
 
-	if opts.topic != "" {
-		req = req.Topic(opts.topic)
-	}
-	if opts.search != "" {
-		req = req.GroupIdFilter(opts.search)
-	}
+		if opts.topic != "" {
+			req = req.Topic(opts.topic)
+		}
+		if opts.search != "" {
+			req = req.GroupIdFilter(opts.search)
+		}
 
-	req = req.Size(opts.size)
+		req = req.Size(opts.size)
 
-	req = req.Page(opts.page)
+		req = req.Page(page)
 
-	consumerGroupData, httpRes, err := req.Execute()
+		return req.Execute()
+	}
+
+	var consumerGroupData kafkainstanceclient.ConsumerGroupList
+	var httpRes *http.Response
+	// the filters are applied on the client, so they need every page to give the right total
+	if opts.listFlags.All || opts.lagging || opts.empty {
+		items := []kafkainstanceclient.ConsumerGroup{}
+		err = listutil.FetchAll(func(page int32) (int, int, error) {
+			var pageData kafkainstanceclient.ConsumerGroupList
+			pageData, httpRes, err = fetchPage(page)
+			if err != nil {
+				return 0, 0, err
+			}
+			items = append(items, pageData.GetItems()...)
+
+			return len(pageData.GetItems()), int(pageData.GetTotal()), nil
+		})
+		consumerGroupData.SetItems(items)
+		consumerGroupData.SetTotal(float32(len(items)))
+		consumerGroupData.SetPage(1)
+		consumerGroupData.SetSize(float32(len(items)))
+	} else {
+		consumerGroupData, httpRes, err = fetchPage(opts.page)
+	}
 	if err != nil {
 		if httpRes == nil {
 			return err
@@ -153,6 +201,12 @@ func runList(opts *Options) (err error) {
 		}
 	}
 
+	if opts.lagging || opts.empty {
+		filtered := filterConsumerGroups(consumerGroupData.GetItems(), opts.lagging, opts.empty)
+		consumerGroupData.SetItems(filtered)
+		consumerGroupData.SetTotal(float32(len(filtered)))
+	}
+
 	ok, err := checkForConsumerGroups(int(consumerGroupData.GetTotal()), opts, kafkaInstance.GetName())
 	if err != nil {
 		return err
@@ -161,6 +215,15 @@ func runList(opts *Options) (err error) {
 		return nil
 	}
 
+	consumerGroups := consumerGroupData.GetItems()
+	rows := mapConsumerGroupResultsToTableFormat(consumerGroups)
+	if opts.listFlags.SortBy != "" {
+		if err = listutil.Sort(rows, opts.listFlags.SortBy, consumerGroups); err != nil {
+			return err
+		}
+		consumerGroupData.SetItems(consumerGroups)
+	}
+
 	switch opts.output {
 	case dump.JSONFormat:
 		data, _ := json.Marshal(consumerGroupData)
@@ -170,11 +233,8 @@ func runList(opts *Options) (err error) {
 		_ = dump.YAML(opts.IO.Out, data)
 	default:
 		logger.Info("")
-		consumerGroups := consumerGroupData.GetItems()
-		rows := mapConsumerGroupResultsToTableFormat(consumerGroups)
-		dump.Table(opts.IO.Out, rows)
 
-		return nil
+		return opts.listFlags.Table(opts.IO.Out, rows)
 	}
 
 	return nil
@@ -187,8 +247,9 @@ func mapConsumerGroupResultsToTableFormat(consumerGroups []kafkainstanceclient.C
 		consumers := t.GetConsumers()
 		row := consumerGroupRow{
 			ConsumerGroupID:   t.GetGroupId(),
-			ActiveMembers:     consumergroup.GetActiveConsumersCount(consumers),
+			ActiveMembers:     consumergroup.GetActiveConsumersCount(consumergroup.FilterConsumersWithMember(consumers)),
 			PartitionsWithLag: consumergroup.GetPartitionsWithLag(consumers),
+			TotalLag:          consumergroup.GetTotalLag(consumers),
 		}
 		rows = append(rows, row)
 	}
@@ -196,6 +257,23 @@ func mapConsumerGroupResultsToTableFormat(consumerGroups []kafkainstanceclient.C
 	return rows
 }
 
+// filterConsumerGroups keeps the consumer groups which have lag and/or no active members
+func filterConsumerGroups(consumerGroups []kafkainstanceclient.ConsumerGroup, lagging bool, empty bool) []kafkainstanceclient.ConsumerGroup {
+	filtered := []kafkainstanceclient.ConsumerGroup{}
+
+	for _, g := range consumerGroups {
+		if lagging && consumergroup.GetPartitionsWithLag(g.GetConsumers()) == 0 {
+			continue
+		}
+		if empty && !consumergroup.IsEmpty(g) {
+			continue
+		}
+		filtered = append(filtered, g)
+	}
+
+	return filtered
+}
+
 // checks if there are any consumer groups available
 // prints to stderr if not
 func checkForConsumerGroups(count int, opts *Options, kafkaName string) (hasCount bool, err error) {
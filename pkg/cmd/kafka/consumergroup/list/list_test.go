@@ -0,0 +1,54 @@
+package list
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestFilterConsumerGroups(t *testing.T) {
+	groups := []kafkainstanceclient.ConsumerGroup{
+		mockutil.NewConsumerGroupMock("active-lagging", mockutil.NewConsumerMock("orders", 0, "member-1", 5)),
+		mockutil.NewConsumerGroupMock("active-caught-up", mockutil.NewConsumerMock("orders", 0, "member-2", 0)),
+		mockutil.NewConsumerGroupMock("empty-lagging", mockutil.NewConsumerMock("orders", 0, "", 3)),
+		mockutil.NewConsumerGroupMock("empty-caught-up", mockutil.NewConsumerMock("orders", 0, "", 0)),
+	}
+
+	tests := []struct {
+		name    string
+		lagging bool
+		empty   bool
+		wantIDs []string
+	}{
+		{
+			name:    "Should keep the groups with lag",
+			lagging: true,
+			wantIDs: []string{"active-lagging", "empty-lagging"},
+		},
+		{
+			name:    "Should keep the groups without active members",
+			empty:   true,
+			wantIDs: []string{"empty-lagging", "empty-caught-up"},
+		},
+		{
+			name:    "Should keep the groups matching both filters",
+			lagging: true,
+			empty:   true,
+			wantIDs: []string{"empty-lagging"},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			ids := []string{}
+			for _, g := range filterConsumerGroups(groups, tt.lagging, tt.empty) {
+				ids = append(ids, g.GetGroupId())
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("filterConsumerGroups() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package resetoffset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	cgutil "github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	kafkaID         string
+	id              string
+	topic           string
+	partitions      []int32
+	to              string
+	value           string
+	credentialsFile string
+	dryRun          bool
+	skipConfirm     bool
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+type offsetRow struct {
+	Topic     string `header:"Topic"`
+	Partition int    `header:"Partition"`
+	Before    string `header:"Offset before"`
+	After     string `header:"Offset after"`
+}
+
+// NewResetOffsetConsumerGroupCommand gets a new command for resetting the offsets of a consumer group.
+func NewResetOffsetConsumerGroupCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		IO:         f.IOStreams,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err = validateFlags(opts); err != nil {
+				return err
+			}
+
+			if !opts.IO.CanPrompt() && !opts.skipConfirm && !opts.dryRun {
+				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.consumerGroup.common.flag.id.description", localize.NewEntry("Action", "reset")))
+	cmd.Flags().StringVar(&opts.topic, "topic", "", opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.topic.description"))
+	cmd.Flags().Int32SliceVar(&opts.partitions, "partitions", []int32{}, opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.partitions.description"))
+	cmd.Flags().StringVar(&opts.to, "to", "", opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.to.description"))
+	cmd.Flags().StringVar(&opts.value, "value", "", opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.value.description"))
+	cmd.Flags().StringVar(&opts.credentialsFile, "credentials-file", "", opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.credentialsFile.description"))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.dryRun.description"))
+	cmd.Flags().BoolVarP(&opts.skipConfirm, "yes", "y", false, opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.flag.yes.description"))
+	_ = cmd.MarkFlagRequired("id")
+	_ = cmd.MarkFlagRequired("topic")
+	_ = cmd.MarkFlagRequired("to")
+
+	// flag based completions for ID
+	_ = cmd.RegisterFlagCompletionFunc("id", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidConsumerGroupIDs(f, toComplete)
+	})
+
+	_ = cmd.RegisterFlagCompletionFunc("topic", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidTopicNameArgs(f, toComplete)
+	})
+
+	flagutil.EnableStaticFlagCompletion(cmd, "to", cgutil.ValidResetOffsets)
+
+	return cmd
+}
+
+// validateFlags checks the offset to reset to and its value,
+// converting a timestamp to the format expected by the API
+func validateFlags(opts *Options) error {
+	if !flagutil.IsValidInput(opts.to, cgutil.ValidResetOffsets...) {
+		return flag.InvalidValueError("to", opts.to, cgutil.ValidResetOffsets...)
+	}
+
+	for _, p := range opts.partitions {
+		if p < 0 {
+			return flag.InvalidValueError("partitions", p)
+		}
+	}
+
+	toTmplPair := localize.NewEntry("To", opts.to)
+
+	switch opts.to {
+	case cgutil.OffsetAbsolute:
+		if opts.value == "" {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.valueRequired", toTmplPair))
+		}
+		if offset, err := strconv.ParseInt(opts.value, 10, 64); err != nil || offset < 0 {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.invalidAbsoluteValue", localize.NewEntry("Value", opts.value)))
+		}
+	case cgutil.OffsetTimestamp:
+		if opts.value == "" {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.valueRequired", toTmplPair))
+		}
+		t, err := time.Parse(time.RFC3339, opts.value)
+		if err != nil {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.invalidTimestampValue", localize.NewEntry("Value", opts.value)))
+		}
+		opts.value = t.UTC().Format(time.RFC3339)
+	default:
+		if opts.value != "" {
+			return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.valueNotAllowed", toTmplPair))
+		}
+	}
+
+	return nil
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	group, httpRes, err := api.GroupsApi.GetConsumerGroupById(ctx, opts.id).Execute()
+	if err != nil {
+		return cgutil.APIError(opts.localizer, httpRes, err, opts.id, kafkaInstance.GetName(), "view")
+	}
+
+	cgIDPair := localize.NewEntry("ID", opts.id)
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	consumers := group.GetConsumers()
+
+	// the offsets of a group can only be moved while no consumer is committing new ones
+	if active := cgutil.GetActiveConsumersCount(cgutil.FilterConsumersWithMember(consumers)); active > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.activeMembers", cgIDPair, localize.NewEntry("Count", active)))
+	}
+
+	tokens, err := messaging.NewTokenSource(opts.CfgHandler.Cfg, opts.credentialsFile)
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.invalidCredentialsFile", localize.NewEntry("File", opts.credentialsFile), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	cfg, err := messaging.ConfigForKafka(kafkaInstance, tokens, opts.CfgHandler.Cfg.Insecure)
+	if err != nil {
+		return err
+	}
+
+	client := messaging.NewClient(cfg)
+	defer client.Close()
+
+	// the API returns offsets as 32-bit floats, so the exact offsets are read with the Kafka protocol
+	committed, err := client.CommittedOffsets(ctx, opts.id, []string{opts.topic})
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.committedOffsets", cgIDPair, localize.NewEntry("ErrorMessage", err)))
+	}
+
+	before := cgutil.CurrentOffsets(committed, opts.topic, opts.partitions)
+
+	if opts.dryRun {
+		var ranges map[int]messaging.OffsetRange
+		if opts.to == cgutil.OffsetLatest {
+			ranges, err = client.OffsetRanges(ctx, opts.topic)
+			if err != nil {
+				return errors.New(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.error.offsetRanges", localize.NewEntry("Topic", opts.topic), localize.NewEntry("ErrorMessage", err)))
+			}
+		}
+
+		rows := make([]offsetRow, len(before))
+		for i, offset := range before {
+			after := cgutil.ExpectedResetOffset(ranges, offset, opts.to, opts.value)
+			if after == "" {
+				after = fmt.Sprintf("(%v)", opts.to)
+			}
+			rows[i] = newOffsetRow(offset, offset.Offset, after)
+		}
+
+		dump.Table(opts.IO.Out, rows)
+		logger.Info("")
+		logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.log.info.dryRun"))
+		return nil
+	}
+
+	if !opts.skipConfirm {
+		var confirmed bool
+		promptConfirm := &survey.Confirm{
+			Message: opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.input.confirm.message", cgIDPair, localize.NewEntry("Topic", opts.topic), localize.NewEntry("To", opts.to)),
+		}
+
+		if err = survey.AskOne(promptConfirm, &confirmed); err != nil {
+			return err
+		}
+
+		if !confirmed {
+			logger.Infoln(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.log.debug.cancelled"))
+			return nil
+		}
+	}
+
+	topicToReset := kafkainstanceclient.NewTopicsToResetOffset(opts.topic)
+	if len(opts.partitions) > 0 {
+		topicToReset.SetPartitions(opts.partitions)
+	}
+
+	params := kafkainstanceclient.NewConsumerGroupResetOffsetParameters(opts.to)
+	params.SetTopics([]kafkainstanceclient.TopicsToResetOffset{*topicToReset})
+	if opts.value != "" {
+		params.SetValue(opts.value)
+	}
+
+	result, httpRes, err := api.GroupsApi.ResetConsumerGroupOffset(ctx, opts.id).ConsumerGroupResetOffsetParameters(*params).Execute()
+	if err != nil {
+		return cgutil.APIError(opts.localizer, httpRes, err, opts.id, kafkaInstance.GetName(), "reset")
+	}
+
+	dump.Table(opts.IO.Out, mapResetToRows(before, cgutil.ResetOffsets(result)))
+	logger.Info("")
+	logger.Info(opts.localizer.LocalizeByID("kafka.consumerGroup.resetOffset.log.info.offsetsReset", cgIDPair, localize.NewEntry("Topic", opts.topic), kafkaNameTmplPair))
+
+	return nil
+}
+
+// mapResetToRows shows the offset of each partition before and after the reset
+func mapResetToRows(before []cgutil.PartitionOffset, after []cgutil.PartitionOffset) []offsetRow {
+	beforeByPartition := map[int32]string{}
+	for _, offset := range before {
+		beforeByPartition[offset.Partition] = offset.Offset
+	}
+
+	rows := make([]offsetRow, len(after))
+	for i, offset := range after {
+		rows[i] = newOffsetRow(offset, beforeByPartition[offset.Partition], offset.Offset)
+	}
+
+	return rows
+}
+
+func newOffsetRow(offset cgutil.PartitionOffset, before string, after string) offsetRow {
+	if before == "" {
+		before = "-"
+	}
+	return offsetRow{
+		Topic:     offset.Topic,
+		Partition: int(offset.Partition),
+		Before:    before,
+		After:     after,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
@@ -13,6 +14,8 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
 	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
+	"github.com/aerogear/charmil-host-example/pkg/spinner"
 
 	"github.com/aerogear/charmil-host-example/pkg/cloudprovider/cloudproviderutil"
 	"github.com/aerogear/charmil-host-example/pkg/cloudregion/cloudregionutil"
@@ -38,9 +41,14 @@ type Options struct {
 	provider string
 	region   string
 	multiAZ  bool
+	file     string
 
-	outputFormat string
-	autoUse      bool
+	outputFormat  string
+	autoUse       bool
+	skipPreflight bool
+	dryRun        bool
+	wait          bool
+	waitTimeout   time.Duration
 
 	interactive bool
 
@@ -56,6 +64,9 @@ const (
 	defaultMultiAZ  = true
 	defaultRegion   = "us-east-1"
 	defaultProvider = "aws"
+
+	// default time to wait for the Kafka instance to be ready
+	defaultWaitTimeout = 30 * time.Minute
 )
 
 // NewCreateCommand creates a new command for creating kafkas.
@@ -77,6 +88,43 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 		Example: opts.localizer.LocalizeByID("kafka.create.cmd.example"),
 		Args:    cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("wait-timeout") && !opts.wait {
+				return errors.New(opts.localizer.LocalizeByID("kafka.create.error.waitTimeoutWithoutWait"))
+			}
+
+			if opts.dryRun && opts.wait {
+				return errors.New(opts.localizer.LocalizeByID("kafka.create.error.dryRunWithWait"))
+			}
+
+			validOutputFormats := flagutil.ValidOutputFormats
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, validOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, validOutputFormats...)
+			}
+
+			if opts.file != "" {
+				if len(args) > 0 || opts.provider != "" || opts.region != "" {
+					return errors.New(opts.localizer.LocalizeByID("kafka.create.error.fileWithOtherInput"))
+				}
+
+				return runCreateFromFile(opts)
+			}
+
+			if opts.dryRun {
+				if len(args) == 0 {
+					return errors.New(opts.localizer.LocalizeByID("kafka.create.error.dryRunRequiresName"))
+				}
+
+				spec := pkgKafka.Spec{
+					Name:     args[0],
+					Provider: opts.provider,
+					Region:   opts.region,
+					MultiAZ:  &opts.multiAZ,
+				}
+				applySpecDefaults(&spec)
+
+				return runDryRun(opts, []pkgKafka.Spec{spec})
+			}
+
 			if len(args) > 0 {
 				validator := &pkgKafka.Validator{
 					Localizer:  opts.localizer,
@@ -98,9 +146,21 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 				opts.interactive = true
 			}
 
-			validOutputFormats := flagutil.ValidOutputFormats
-			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, validOutputFormats...) {
-				return flag.InvalidValueError("output", opts.outputFormat, validOutputFormats...)
+			if !opts.interactive && (opts.provider != "" || opts.region != "") {
+				if opts.provider == "" {
+					opts.provider = defaultProvider
+				}
+				if opts.region == "" {
+					opts.region = defaultRegion
+				}
+
+				validator := &pkgKafka.Validator{
+					Localizer:  opts.localizer,
+					Connection: opts.Connection,
+				}
+				if err := validator.ValidateCloudProviderRegion(opts.provider, opts.region); err != nil {
+					return err
+				}
 			}
 
 			return runCreate(opts)
@@ -109,18 +169,59 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.provider, flags.FlagProvider, "", opts.localizer.LocalizeByID("kafka.create.flag.cloudProvider.description"))
 	cmd.Flags().StringVar(&opts.region, flags.FlagRegion, "", opts.localizer.LocalizeByID("kafka.create.flag.cloudRegion.description"))
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", opts.localizer.LocalizeByID("kafka.create.flag.file.description"))
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "json", opts.localizer.LocalizeByID("kafka.common.flag.output.description"))
 	cmd.Flags().BoolVar(&opts.autoUse, "use", true, opts.localizer.LocalizeByID("kafka.create.flag.autoUse.description"))
+	cmd.Flags().BoolVar(&opts.wait, "wait", false, opts.localizer.LocalizeByID("kafka.create.flag.wait.description"))
+	cmd.Flags().DurationVar(&opts.waitTimeout, "wait-timeout", defaultWaitTimeout, opts.localizer.LocalizeByID("kafka.create.flag.waitTimeout.description"))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, opts.localizer.LocalizeByID("kafka.create.flag.dryRun.description"))
+	preflight.AddFlag(cmd, &opts.skipPreflight, opts.localizer)
 
 	_ = cmd.RegisterFlagCompletionFunc(flags.FlagProvider, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 		return cmdutil.FetchCloudProviders(f)
 	})
+	_ = cmd.RegisterFlagCompletionFunc(flags.FlagRegion, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		provider, _ := cmd.Flags().GetString(flags.FlagProvider)
+		return cmdutil.FetchCloudRegions(f, provider)
+	})
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
 	return cmd
 }
 
+// checkKafkaQuota checks that the organization of the user has quota remaining for count Kafka instances,
+// so that the user does not have to wait for the request to be rejected.
+// The check is best-effort: when the quota cannot be read, creation continues.
+func checkKafkaQuota(opts *Options, conn connection.Connection, logger logging.Logger, count int) error {
+	quota, err := ams.GetKafkaQuota(conn)
+	if err != nil {
+		logger.Info(opts.localizer.LocalizeByID("kafka.create.log.info.quotaCheckFailed", localize.NewEntry("ErrorMessage", err)))
+		return nil
+	}
+
+	if quota == nil {
+		logger.Info(opts.localizer.LocalizeByID("kafka.create.log.info.noQuota"))
+		return nil
+	}
+
+	allowedEntry := localize.NewEntry("Allowed", quota.Allowed)
+	consumedEntry := localize.NewEntry("Consumed", quota.Consumed)
+
+	if quota.Remaining < 1 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.create.error.quotaExceeded", allowedEntry, consumedEntry))
+	}
+
+	if quota.Remaining < count {
+		return errors.New(opts.localizer.LocalizeByID("kafka.create.error.quotaInsufficient",
+			localize.NewEntry("Requested", count), localize.NewEntry("Remaining", quota.Remaining), allowedEntry, consumedEntry))
+	}
+
+	logger.Infoln(opts.localizer.LocalizeByID("kafka.create.log.debug.quotaRemaining", localize.NewEntry("Remaining", quota.Remaining), allowedEntry, consumedEntry))
+
+	return nil
+}
+
 // nolint:funlen
 func runCreate(opts *Options) error {
 	logger, err := opts.Logger()
@@ -133,6 +234,12 @@ func runCreate(opts *Options) error {
 		return err
 	}
 
+	if !opts.skipPreflight {
+		if err = preflight.Check(connection, opts.localizer, preflight.CreateKafka); err != nil {
+			return err
+		}
+	}
+
 	// the user must have accepted the terms and conditions from the provider
 	// before they can create a kafka instance
 	termsAccepted, termsURL, err := ams.CheckTermsAccepted(connection)
@@ -144,6 +251,10 @@ func runCreate(opts *Options) error {
 		return nil
 	}
 
+	if err = checkKafkaQuota(opts, connection, logger, 1); err != nil {
+		return err
+	}
+
 	var payload *kafkamgmtclient.KafkaRequestPayload
 	if opts.interactive {
 		logger.Infoln()
@@ -186,7 +297,17 @@ func runCreate(opts *Options) error {
 		return err
 	}
 
-	logger.Info(opts.localizer.LocalizeByID("kafka.create.info.successMessage", localize.NewEntry("Name", response.GetName())))
+	if opts.wait {
+		kafkaInstance, waitErr := waitForKafka(opts, api.Kafka(), response.GetId(), response.GetName())
+		if waitErr != nil {
+			return waitErr
+		}
+		response = *kafkaInstance
+
+		logger.Info(opts.localizer.LocalizeByID("kafka.create.info.readyMessage", localize.NewEntry("Name", response.GetName())))
+	} else {
+		logger.Info(opts.localizer.LocalizeByID("kafka.create.info.successMessage", localize.NewEntry("Name", response.GetName())))
+	}
 
 	switch opts.outputFormat {
 	case dump.JSONFormat:
@@ -212,6 +333,38 @@ func runCreate(opts *Options) error {
 	return nil
 }
 
+// waitForKafka polls the Kafka instance until it is ready, showing its progress.
+// An error is returned when provisioning fails or the instance is not ready within --wait-timeout.
+func waitForKafka(opts *Options, api kafkamgmtclient.DefaultApi, id string, name string) (*kafkamgmtclient.KafkaRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), opts.waitTimeout)
+	defer cancel()
+
+	s := spinner.New(opts.IO)
+	s.SetMessage(opts.localizer.LocalizeByID("kafka.create.log.info.waiting", localize.NewEntry("Name", name), localize.NewEntry("Status", pkgKafka.StatusAccepted)))
+	s.Start()
+
+	kafkaInstance, err := pkgKafka.WaitForReady(ctx, api, id, func(k *kafkamgmtclient.KafkaRequest) {
+		s.SetMessage(opts.localizer.LocalizeByID("kafka.create.log.info.waiting", localize.NewEntry("Name", name), localize.NewEntry("Status", k.GetStatus())))
+	})
+	s.Stop()
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.create.error.waitTimeout", localize.NewEntry("Name", name), localize.NewEntry("Timeout", opts.waitTimeout)))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch kafkaInstance.GetStatus() {
+	case pkgKafka.StatusFailed:
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.create.error.provisioningFailed", localize.NewEntry("Name", name), localize.NewEntry("Reason", kafkaInstance.GetFailedReason())))
+	case pkgKafka.StatusDeprovision, pkgKafka.StatusDeleting:
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.create.error.deletedWhileWaiting", localize.NewEntry("Name", name)))
+	}
+
+	return kafkaInstance, nil
+}
+
 // Show a prompt to allow the user to interactively insert the data for their Kafka
 func promptKafkaPayload(opts *Options) (payload *kafkamgmtclient.KafkaRequestPayload, err error) {
 	connection, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
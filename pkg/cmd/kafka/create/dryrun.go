@@ -0,0 +1,217 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/aerogear/charmil-host-example/pkg/ams"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	pkgKafka "github.com/aerogear/charmil-host-example/pkg/kafka"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
+	"github.com/aerogear/charmil/core/utils/localize"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"gopkg.in/yaml.v2"
+)
+
+// checks performed by a dry run
+const (
+	checkPermissions    = "permissions"
+	checkTerms          = "terms"
+	checkName           = "name"
+	checkNameAvailable  = "name_available"
+	checkProviderRegion = "provider_region"
+	checkQuota          = "quota"
+)
+
+// results of a dry run check
+const (
+	checkPassed  = "pass"
+	checkFailed  = "fail"
+	checkSkipped = "skip"
+)
+
+// checkResult is the outcome of a single dry run check
+type checkResult struct {
+	Check    string `json:"check" yaml:"check"`
+	Instance string `json:"instance,omitempty" yaml:"instance,omitempty"`
+	Result   string `json:"result" yaml:"result"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// dryRunResult is the outcome of a dry run, with the payloads which would be sent
+type dryRunResult struct {
+	Result   string                                `json:"result" yaml:"result"`
+	Checks   []checkResult                         `json:"checks" yaml:"checks"`
+	Payloads []kafkamgmtclient.KafkaRequestPayload `json:"payloads" yaml:"payloads"`
+}
+
+func (r *dryRunResult) add(check string, instance string, err error) {
+	result := checkResult{Check: check, Instance: instance, Result: checkPassed}
+	if err != nil {
+		result.Result = checkFailed
+		result.Message = err.Error()
+	}
+	r.Checks = append(r.Checks, result)
+}
+
+func (r *dryRunResult) skip(check string, instance string, message string) {
+	r.Checks = append(r.Checks, checkResult{Check: check, Instance: instance, Result: checkSkipped, Message: message})
+}
+
+// runDryRun performs every check of creating the Kafka instances without creating them.
+// Every check is run even when a previous one has failed, so that all problems are reported at once.
+// nolint:funlen
+func runDryRun(opts *Options, specs []pkgKafka.Spec) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	validator := &pkgKafka.Validator{
+		Localizer:  opts.localizer,
+		Connection: opts.Connection,
+	}
+
+	result := &dryRunResult{
+		Checks:   []checkResult{},
+		Payloads: []kafkamgmtclient.KafkaRequestPayload{},
+	}
+
+	skippedMessage := opts.localizer.LocalizeByID("kafka.create.dryRun.skippedPreflight")
+
+	if opts.skipPreflight {
+		result.skip(checkPermissions, "", skippedMessage)
+	} else {
+		result.add(checkPermissions, "", preflight.Check(conn, opts.localizer, preflight.CreateKafka))
+	}
+
+	termsAccepted, termsURL, err := ams.CheckTermsAccepted(conn)
+	if err == nil && !termsAccepted {
+		err = errors.New(opts.localizer.LocalizeByID("kafka.create.dryRun.termsNotAccepted", localize.NewEntry("TermsURL", termsURL)))
+	}
+	result.add(checkTerms, "", err)
+
+	// only instances which do not exist yet consume quota, and each name only once
+	newInstances := 0
+	names := map[string]bool{}
+	for i := range specs {
+		spec := &specs[i]
+
+		nameErr := validator.ValidateName(spec.Name)
+		duplicate := nameErr == nil && names[spec.Name]
+		if duplicate {
+			nameErr = errors.New(opts.localizer.LocalizeByID("kafka.create.error.duplicateSpecName", localize.NewEntry("Name", spec.Name)))
+		}
+		names[spec.Name] = true
+		result.add(checkName, spec.Name, nameErr)
+
+		exists := false
+		switch {
+		case duplicate:
+			result.skip(checkNameAvailable, spec.Name, opts.localizer.LocalizeByID("kafka.create.dryRun.skippedDuplicate"))
+		case nameErr != nil:
+			result.skip(checkNameAvailable, spec.Name, opts.localizer.LocalizeByID("kafka.create.dryRun.skippedInvalidName"))
+		case opts.file != "":
+			// instances of a spec file which already exist are skipped when creating them
+			existingID, existsErr := findExistingKafka(context.Background(), conn.API().Kafka(), spec.Name)
+			exists = existingID != ""
+			if exists {
+				result.skip(checkNameAvailable, spec.Name, opts.localizer.LocalizeByID("kafka.create.dryRun.skippedExists"))
+				break
+			}
+			result.add(checkNameAvailable, spec.Name, existsErr)
+			if existsErr == nil {
+				newInstances++
+			}
+		default:
+			availableErr := validator.ValidateNameIsAvailable(spec.Name)
+			result.add(checkNameAvailable, spec.Name, availableErr)
+			if availableErr == nil {
+				newInstances++
+			}
+		}
+
+		result.add(checkProviderRegion, spec.Name, validator.ValidateCloudProviderRegion(spec.Provider, spec.Region))
+
+		if !exists && !duplicate {
+			result.Payloads = append(result.Payloads, kafkamgmtclient.KafkaRequestPayload{
+				Name:          spec.Name,
+				CloudProvider: &spec.Provider,
+				Region:        &spec.Region,
+				MultiAz:       spec.MultiAZ,
+			})
+		}
+	}
+
+	addQuotaCheck(opts, conn, result, newInstances)
+
+	failed := 0
+	for _, c := range result.Checks {
+		if c.Result == checkFailed {
+			failed++
+		}
+	}
+
+	result.Result = checkPassed
+	if failed > 0 {
+		result.Result = checkFailed
+	}
+
+	switch opts.outputFormat {
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(result)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		data, _ := json.MarshalIndent(result, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	}
+
+	if failed > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.create.error.dryRunFailed", localize.NewEntry("Count", failed)))
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.create.log.info.dryRunPassed"))
+
+	return nil
+}
+
+// addQuotaCheck checks that the organization has quota for the instances which would be created
+func addQuotaCheck(opts *Options, conn connection.Connection, result *dryRunResult, newInstances int) {
+	quota, err := ams.GetKafkaQuota(conn)
+	if err != nil {
+		result.add(checkQuota, "", err)
+		return
+	}
+
+	if quota == nil {
+		result.Checks = append(result.Checks, checkResult{
+			Check:   checkQuota,
+			Result:  checkPassed,
+			Message: opts.localizer.LocalizeByID("kafka.create.log.info.noQuota"),
+		})
+		return
+	}
+
+	allowedEntry := localize.NewEntry("Allowed", quota.Allowed)
+	consumedEntry := localize.NewEntry("Consumed", quota.Consumed)
+
+	if quota.Remaining < newInstances {
+		result.add(checkQuota, "", errors.New(opts.localizer.LocalizeByID("kafka.create.dryRun.quotaExceeded",
+			localize.NewEntry("Requested", newInstances), localize.NewEntry("Remaining", quota.Remaining), allowedEntry, consumedEntry)))
+		return
+	}
+
+	result.Checks = append(result.Checks, checkResult{
+		Check:   checkQuota,
+		Result:  checkPassed,
+		Message: opts.localizer.LocalizeByID("kafka.create.log.debug.quotaRemaining", localize.NewEntry("Remaining", quota.Remaining), allowedEntry, consumedEntry),
+	})
+}
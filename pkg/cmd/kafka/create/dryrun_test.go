@@ -0,0 +1,154 @@
+package create
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/pkg/ams"
+	"github.com/aerogear/charmil-host-example/pkg/api"
+	"github.com/aerogear/charmil-host-example/pkg/api/ams/amsclient"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/localesettings"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"golang.org/x/text/language"
+)
+
+func newTestLocalizer(t *testing.T) localize.Localizer {
+	localizer, err := localize.New(&localize.Config{
+		Language: &language.English,
+		Files:    localesettings.DefaultLocales,
+		Format:   "toml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return localizer
+}
+
+// newAMSMock accepts the terms and grants the quota for the given number of Kafka instances
+func newAMSMock(allowed int32) amsclient.DefaultApi {
+	amsMock := &amsclient.DefaultApiMock{}
+	amsMock.ApiAuthorizationsV1SelfTermsReviewPostFunc = func(ctx context.Context) amsclient.ApiApiAuthorizationsV1SelfTermsReviewPostRequest {
+		return amsclient.ApiApiAuthorizationsV1SelfTermsReviewPostRequest{ApiService: amsMock}
+	}
+	amsMock.ApiAuthorizationsV1SelfTermsReviewPostExecuteFunc = func(r amsclient.ApiApiAuthorizationsV1SelfTermsReviewPostRequest) (amsclient.TermsReviewResponse, *http.Response, error) {
+		return amsclient.TermsReviewResponse{}, nil, nil
+	}
+	amsMock.ApiAccountsMgmtV1CurrentAccountGetFunc = func(ctx context.Context) amsclient.ApiApiAccountsMgmtV1CurrentAccountGetRequest {
+		return amsclient.ApiApiAccountsMgmtV1CurrentAccountGetRequest{ApiService: amsMock}
+	}
+	amsMock.ApiAccountsMgmtV1CurrentAccountGetExecuteFunc = func(r amsclient.ApiApiAccountsMgmtV1CurrentAccountGetRequest) (amsclient.Account, *http.Response, error) {
+		org := amsclient.Organization{}
+		org.SetId("org-1")
+		account := amsclient.Account{}
+		account.SetOrganization(org)
+		return account, nil, nil
+	}
+	amsMock.ApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetFunc = func(ctx context.Context, orgID string) amsclient.ApiApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetRequest {
+		return amsclient.ApiApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetRequest{ApiService: amsMock}
+	}
+	amsMock.ApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetExecuteFunc = func(r amsclient.ApiApiAccountsMgmtV1OrganizationsOrgIdQuotaCostGetRequest) (amsclient.QuotaCostList, *http.Response, error) {
+		cost := amsclient.QuotaCost{
+			QuotaId:          "quota-kafka",
+			Allowed:          allowed,
+			RelatedResources: &[]amsclient.RelatedResource{{Product: ams.KafkaProduct, Cost: 1}},
+		}
+		return amsclient.QuotaCostList{Items: []amsclient.QuotaCost{cost}}, nil, nil
+	}
+
+	return amsMock
+}
+
+// newKafkaMgmtServer serves no Kafka instances and the default cloud provider and region
+func newKafkaMgmtServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/kafkas_mgmt/v1/cloud_providers":
+			provider := kafkamgmtclient.CloudProvider{}
+			provider.SetId(defaultProvider)
+			provider.SetName(defaultProvider)
+			provider.SetEnabled(true)
+			_ = json.NewEncoder(w).Encode(kafkamgmtclient.CloudProviderList{Items: []kafkamgmtclient.CloudProvider{provider}})
+		case "/api/kafkas_mgmt/v1/cloud_providers/" + defaultProvider + "/regions":
+			region := kafkamgmtclient.CloudRegion{}
+			region.SetId(defaultRegion)
+			region.SetEnabled(true)
+			_ = json.NewEncoder(w).Encode(kafkamgmtclient.CloudRegionList{Items: []kafkamgmtclient.CloudRegion{region}})
+		default:
+			_ = json.NewEncoder(w).Encode(kafkamgmtclient.KafkaRequestList{Items: []kafkamgmtclient.KafkaRequest{}})
+		}
+	}))
+}
+
+func TestRunDryRunDuplicateName(t *testing.T) {
+	server := newKafkaMgmtServer()
+	defer server.Close()
+
+	cfg := kafkamgmtclient.NewConfiguration()
+	cfg.Servers = kafkamgmtclient.ServerConfigurations{{URL: server.URL}}
+	kafkaAPI := kafkamgmtclient.NewAPIClient(cfg).DefaultApi
+	amsMock := newAMSMock(1)
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	logger, err := logging.NewStdLoggerBuilder().Streams(out, errOut).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	specFile := "name: my-kafka\n---\nname: my-kafka\n"
+
+	opts := &Options{
+		file:          "-",
+		dryRun:        true,
+		skipPreflight: true,
+		IO:            &iostreams.IOStreams{In: ioutil.NopCloser(strings.NewReader(specFile)), Out: out, ErrOut: errOut},
+		Connection: func(*connection.Config) (connection.Connection, error) {
+			return &connection.ConnectionMock{
+				APIFunc: func() *api.API {
+					return &api.API{
+						Kafka:       func() kafkamgmtclient.DefaultApi { return kafkaAPI },
+						AccountMgmt: func() amsclient.DefaultApi { return amsMock },
+					}
+				},
+			}, nil
+		},
+		Logger:    func() (logging.Logger, error) { return logger, nil },
+		localizer: newTestLocalizer(t),
+	}
+
+	if err = runCreateFromFile(opts); err == nil {
+		t.Fatal("runCreateFromFile() error = nil, want the dry run to fail")
+	}
+
+	var result dryRunResult
+	if err = json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("dry run output is not JSON: %v\n%s", err, out.String())
+	}
+
+	failed := []checkResult{}
+	for _, c := range result.Checks {
+		if c.Result == checkFailed {
+			failed = append(failed, c)
+		}
+		if c.Check == checkQuota && c.Result != checkPassed {
+			t.Errorf("quota check = %v, want the duplicated instance to be counted once", c)
+		}
+	}
+	if len(failed) != 1 || failed[0].Check != checkName || failed[0].Instance != "my-kafka" {
+		t.Errorf("failed checks = %v, want only the name check of my-kafka", failed)
+	}
+	if len(result.Payloads) != 1 {
+		t.Errorf("payloads = %v, want a single payload", result.Payloads)
+	}
+}
@@ -0,0 +1,274 @@
+package create
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/aerogear/charmil-host-example/pkg/ams"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	pkgKafka "github.com/aerogear/charmil-host-example/pkg/kafka"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
+	"github.com/aerogear/charmil/core/utils/localize"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"gopkg.in/yaml.v2"
+)
+
+// results of creating an instance from a spec file
+const (
+	resultCreated = "created"
+	resultSkipped = "skipped"
+	resultFailed  = "failed"
+)
+
+// specResult is the outcome of creating a single instance from the spec file
+type specResult struct {
+	Name   string `json:"name" yaml:"name"`
+	ID     string `json:"id,omitempty" yaml:"id,omitempty"`
+	Result string `json:"result" yaml:"result"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// runCreateFromFile creates every Kafka instance defined in the spec file.
+// All specs are validated before any instance is created, and instances
+// which already exist by name are skipped.
+// nolint:funlen
+func runCreateFromFile(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	specs, err := readSpecFile(opts)
+	if err != nil {
+		return err
+	}
+
+	if len(specs) == 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.create.error.emptySpecFile", localize.NewEntry("File", opts.file)))
+	}
+
+	if opts.dryRun {
+		for i := range specs {
+			applySpecDefaults(&specs[i])
+		}
+		return runDryRun(opts, specs)
+	}
+
+	if err = validateSpecs(opts, specs); err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	if !opts.skipPreflight {
+		if err = preflight.Check(conn, opts.localizer, preflight.CreateKafka); err != nil {
+			return err
+		}
+	}
+
+	termsAccepted, termsURL, err := ams.CheckTermsAccepted(conn)
+	if err != nil {
+		return err
+	}
+	if !termsAccepted && termsURL != "" {
+		logger.Info(opts.localizer.LocalizeByID("service.info.termsCheck", localize.NewEntry("TermsURL", termsURL)))
+		return nil
+	}
+
+	api := conn.API()
+	ctx := context.Background()
+
+	// look up the instances first, as only those which do not exist yet consume quota
+	results := make([]specResult, len(specs))
+	newInstances := 0
+	for i, spec := range specs {
+		results[i].Name = spec.Name
+
+		existingID, err := findExistingKafka(ctx, api.Kafka(), spec.Name)
+		if err != nil {
+			results[i].Result = resultFailed
+			results[i].Error = err.Error()
+			continue
+		}
+
+		if existingID != "" {
+			results[i].ID = existingID
+			results[i].Result = resultSkipped
+			logger.Info(opts.localizer.LocalizeByID("kafka.create.log.info.skippingExisting", localize.NewEntry("Name", spec.Name)))
+			continue
+		}
+
+		newInstances++
+	}
+
+	if newInstances > 0 {
+		if err = checkKafkaQuota(opts, conn, logger, newInstances); err != nil {
+			return err
+		}
+	}
+
+	for i, spec := range specs {
+		if results[i].Result != "" {
+			continue
+		}
+
+		nameEntry := localize.NewEntry("Name", spec.Name)
+		result := &results[i]
+
+		logger.Info(opts.localizer.LocalizeByID("kafka.create.log.info.creatingKafka", nameEntry))
+
+		payload := kafkamgmtclient.KafkaRequestPayload{
+			Name:          spec.Name,
+			Region:        &spec.Region,
+			CloudProvider: &spec.Provider,
+			MultiAz:       spec.MultiAZ,
+		}
+
+		response, httpRes, err := api.Kafka().CreateKafka(ctx).KafkaRequestPayload(payload).Async(true).Execute()
+		if httpRes != nil && httpRes.StatusCode == 409 {
+			err = errors.New(opts.localizer.LocalizeByID("kafka.create.error.conflictError", nameEntry))
+		}
+		if err != nil {
+			result.Result = resultFailed
+			result.Error = err.Error()
+			logger.Info(opts.localizer.LocalizeByID("kafka.create.log.info.createFailed", nameEntry, localize.NewEntry("ErrorMessage", err)))
+			continue
+		}
+
+		result.ID = response.GetId()
+		result.Result = resultCreated
+	}
+
+	if opts.wait {
+		for i, result := range results {
+			if result.Result != resultCreated {
+				continue
+			}
+
+			if _, err = waitForKafka(opts, api.Kafka(), result.ID, result.Name); err != nil {
+				results[i].Result = resultFailed
+				results[i].Error = err.Error()
+			}
+		}
+	}
+
+	printSpecResults(opts, results)
+
+	failed := 0
+	for _, result := range results {
+		if result.Result == resultFailed {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.create.error.specsFailed", localize.NewEntry("Count", failed)))
+	}
+
+	// only a single instance can be the current instance,
+	// so auto-use applies when the file defines exactly one
+	if opts.autoUse && len(results) == 1 && results[0].Result == resultCreated {
+		logger.Infoln("Auto-use is set, updating the current instance")
+		opts.CfgHandler.Cfg.Services.Kafka = &config.KafkaConfig{
+			ClusterID: results[0].ID,
+		}
+	}
+
+	return nil
+}
+
+// findExistingKafka returns the ID of the Kafka instance with the given name,
+// or an empty string when it does not exist
+func findExistingKafka(ctx context.Context, api kafkamgmtclient.DefaultApi, name string) (string, error) {
+	existing, _, err := pkgKafka.FindKafkaByName(ctx, api, name)
+	if err != nil || existing == nil {
+		return "", err
+	}
+
+	return existing.GetId(), nil
+}
+
+// readSpecFile reads the Kafka instance specs from --file, or from standard input when it is "-"
+func readSpecFile(opts *Options) ([]pkgKafka.Spec, error) {
+	var r io.Reader
+	if opts.file == "-" {
+		r = opts.IO.In
+	} else {
+		f, err := os.Open(opts.file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	specs, err := pkgKafka.ReadSpecs(r)
+	if err != nil {
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.create.error.invalidSpecFile", localize.NewEntry("File", opts.file), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	return specs, nil
+}
+
+// validateSpecs applies the default values to each spec and validates them
+func validateSpecs(opts *Options, specs []pkgKafka.Spec) error {
+	validator := &pkgKafka.Validator{
+		Localizer:  opts.localizer,
+		Connection: opts.Connection,
+	}
+
+	names := map[string]bool{}
+	for i := range specs {
+		spec := &specs[i]
+		applySpecDefaults(spec)
+
+		if err := validator.ValidateName(spec.Name); err != nil {
+			return err
+		}
+
+		if names[spec.Name] {
+			return errors.New(opts.localizer.LocalizeByID("kafka.create.error.duplicateSpecName", localize.NewEntry("Name", spec.Name)))
+		}
+		names[spec.Name] = true
+
+		if err := validator.ValidateCloudProviderRegion(spec.Provider, spec.Region); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applySpecDefaults sets the default values of the fields which are not set in the spec
+func applySpecDefaults(spec *pkgKafka.Spec) {
+	if spec.Provider == "" {
+		spec.Provider = defaultProvider
+	}
+	if spec.Region == "" {
+		spec.Region = defaultRegion
+	}
+	if spec.MultiAZ == nil {
+		multiAZ := defaultMultiAZ
+		spec.MultiAZ = &multiAZ
+	}
+}
+
+func printSpecResults(opts *Options, results []specResult) {
+	switch opts.outputFormat {
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(results)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		data, _ := json.MarshalIndent(results, "", cmdutil.DefaultJSONIndent)
+		_ = dump.JSON(opts.IO.Out, data)
+	}
+}
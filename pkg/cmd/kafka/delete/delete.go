@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
 	"github.com/aerogear/charmil/core/utils/localize"
 
 	"github.com/aerogear/charmil-host-example/pkg/kafka"
@@ -16,6 +17,7 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 	"github.com/spf13/cobra"
 
@@ -23,9 +25,10 @@ import (
 )
 
 type options struct {
-	id    string
-	name  string
-	force bool
+	id            string
+	name          string
+	force         bool
+	skipPreflight bool
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -50,12 +53,18 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 		Long:    opts.localizer.LocalizeByID("kafka.delete.cmd.longDescription"),
 		Example: opts.localizer.LocalizeByID("kafka.delete.cmd.example"),
 		Args:    cobra.RangeArgs(0, 1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cmdutil.FilterValidKafkas(f, toComplete)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if !opts.IO.CanPrompt() && !opts.force {
 				return flag.RequiredWhenNonInteractiveError("yes")
 			}
 
 			if len(args) > 0 {
+				if opts.name != "" {
+					return errors.New(opts.localizer.LocalizeByID("kafka.common.error.nameArgAndFlag"))
+				}
 				opts.name = args[0]
 			}
 
@@ -79,7 +88,13 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.delete.flag.id"))
+	cmd.Flags().StringVar(&opts.name, "name", "", opts.localizer.LocalizeByID("kafka.delete.flag.name"))
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.LocalizeByID("kafka.delete.flag.yes"))
+	preflight.AddFlag(cmd, &opts.skipPreflight, opts.localizer)
+
+	_ = cmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidKafkas(f, toComplete)
+	})
 
 	return cmd
 }
@@ -95,6 +110,12 @@ func runDelete(opts *options) error {
 		return err
 	}
 
+	if !opts.skipPreflight {
+		if err = preflight.Check(connection, opts.localizer, preflight.DeleteKafka); err != nil {
+			return err
+		}
+	}
+
 	api := connection.API()
 
 	var response *kafkamgmtclient.KafkaRequest
@@ -60,6 +60,9 @@ func NewDescribeCommand(f *factory.Factory) *cobra.Command {
 			}
 
 			if len(args) > 0 {
+				if opts.name != "" {
+					return errors.New(opts.localizer.LocalizeByID("kafka.common.error.nameArgAndFlag"))
+				}
 				opts.name = args[0]
 			}
 
@@ -84,6 +87,11 @@ func NewDescribeCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "json", opts.localizer.LocalizeByID("kafka.common.flag.output.description"))
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.describe.flag.id"))
+	cmd.Flags().StringVar(&opts.name, "name", "", opts.localizer.LocalizeByID("kafka.describe.flag.name"))
+
+	_ = cmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidKafkas(f, toComplete)
+	})
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
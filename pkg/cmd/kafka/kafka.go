@@ -3,6 +3,7 @@
 package kafka
 
 import (
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/acl"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/consumergroup"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic"
 	"github.com/spf13/cobra"
@@ -12,6 +13,9 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/delete"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/describe"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/list"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/metrics"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/providers"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/regions"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/use"
 )
 
@@ -28,9 +32,13 @@ func NewKafkaCommand(f *factory.Factory) *cobra.Command {
 		describe.NewDescribeCommand(f),
 		delete.NewDeleteCommand(f),
 		list.NewListCommand(f),
+		metrics.NewMetricsCommand(f),
+		providers.NewProvidersCommand(f),
+		regions.NewRegionsCommand(f),
 		use.NewUseCommand(f),
 		topic.NewTopicCommand(f),
 		consumergroup.NewConsumerGroupCommand(f),
+		acl.NewAclCommand(f),
 	)
 
 	return cmd
@@ -3,10 +3,14 @@ package list
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
 	"github.com/aerogear/charmil-host-example/pkg/kafka"
 	"github.com/aerogear/charmil/core/utils/iostreams"
@@ -34,13 +38,20 @@ type kafkaRow struct {
 	Status        string `json:"status" header:"Status"`
 	CloudProvider string `json:"cloud_provider" header:"Cloud Provider"`
 	Region        string `json:"region" header:"Region"`
+	MultiAZ       bool   `json:"multi_az" header:"Multi AZ"`
+	CreatedAt     string `json:"created_at" header:"Created At"`
+	Age           int64  `json:"age" header:"Age,timestamp(human)" sort:"desc"`
 }
 
+// defaultColumns are the columns printed in the table when --columns is not set
+var defaultColumns = []string{"id", "name", "owner", "status", "cloud_provider", "region", "multi_az", "age"}
+
 type options struct {
 	outputFormat string
 	page         int
 	limit        int
 	search       string
+	listFlags    listutil.Flags
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -52,7 +63,7 @@ type options struct {
 // NewListCommand creates a new command for listing kafkas.
 func NewListCommand(f *factory.Factory) *cobra.Command {
 	opts := &options{
-		page:       0,
+		page:       cmdutil.DefaultPageNumber,
 		limit:      100,
 		search:     "",
 		CfgHandler: f.CfgHandler,
@@ -63,15 +74,32 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   opts.localizer.LocalizeByID("kafka.list.cmd.use"),
-		Short: opts.localizer.LocalizeByID("kafka.list.cmd.shortDescription"),
-		Long:  opts.localizer.LocalizeByID("kafka.list.cmd.longDescription"),
-		Args:  cobra.NoArgs,
+		Use:     opts.localizer.LocalizeByID("kafka.list.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.list.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.list.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.list.cmd.example"),
+		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
 				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
 			}
 
+			if opts.page < 1 {
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.page.error.invalid.minValue", localize.NewEntry("Page", opts.page)))
+			}
+
+			if opts.limit < 1 {
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.size.error.invalid.minValue", localize.NewEntry("Size", opts.limit)))
+			}
+
+			if opts.listFlags.All && cmd.Flags().Changed("page") {
+				return errors.New(opts.localizer.LocalizeByID("list.error.allWithPage"))
+			}
+
+			if err := opts.listFlags.Validate(kafkaRow{}); err != nil {
+				return err
+			}
+
 			validator := &kafka.Validator{
 				Localizer: opts.localizer,
 			}
@@ -85,9 +113,11 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafkas.common.flag.output.description"))
-	cmd.Flags().IntVarP(&opts.page, "page", "", 0, opts.localizer.LocalizeByID("kafka.list.flag.page"))
+	cmd.Flags().IntVarP(&opts.page, "page", "", cmdutil.DefaultPageNumber, opts.localizer.LocalizeByID("kafka.list.flag.page"))
 	cmd.Flags().IntVarP(&opts.limit, "limit", "", 100, opts.localizer.LocalizeByID("kafka.list.flag.limit"))
 	cmd.Flags().StringVarP(&opts.search, "search", "", "", opts.localizer.LocalizeByID("kafka.list.flag.search"))
+	listutil.AddPaginationFlags(cmd, &opts.listFlags, opts.localizer)
+	listutil.AddTableFlags(cmd, &opts.listFlags, kafkaRow{}, defaultColumns, opts.localizer)
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -107,17 +137,43 @@ func runList(opts *options) error {
 
 	api := connection.API()
 
-	a := api.Kafka().GetKafkas(context.Background())
-	a = a.Page(strconv.Itoa(opts.page))
-	a = a.Size(strconv.Itoa(opts.limit))
-
+	var query string
 	if opts.search != "" {
-		query := buildQuery(opts.search)
+		query = buildQuery(opts.search)
 		logger.Infoln(opts.localizer.LocalizeByID("kafka.list.log.debug.filteringKafkaList", localize.NewEntry("Search", query)))
-		a = a.Search(query)
 	}
 
-	response, _, err := a.Execute()
+	fetchPage := func(page int) (kafkamgmtclient.KafkaRequestList, error) {
+		a := api.Kafka().GetKafkas(context.Background())
+		a = a.Page(strconv.Itoa(page))
+		a = a.Size(strconv.Itoa(opts.limit))
+
+		if query != "" {
+			a = a.Search(query)
+		}
+
+		response, _, err := a.Execute()
+		return response, err
+	}
+
+	var response kafkamgmtclient.KafkaRequestList
+	if opts.listFlags.All {
+		err = listutil.FetchAll(func(page int32) (int, int, error) {
+			pageResponse, pageErr := fetchPage(int(page))
+			if pageErr != nil {
+				return 0, 0, pageErr
+			}
+			response.Kind = pageResponse.Kind
+			response.Total = pageResponse.Total
+			response.Items = append(response.Items, pageResponse.Items...)
+
+			return len(pageResponse.Items), int(pageResponse.Total), nil
+		})
+		response.Page = 1
+		response.Size = int32(len(response.Items))
+	} else {
+		response, err = fetchPage(opts.page)
+	}
 	if err != nil {
 		return err
 	}
@@ -127,6 +183,15 @@ func runList(opts *options) error {
 		return nil
 	}
 
+	items := response.GetItems()
+	rows := mapResponseItemsToRows(items)
+	if opts.listFlags.SortBy != "" {
+		if err = listutil.Sort(rows, opts.listFlags.SortBy, items); err != nil {
+			return err
+		}
+		response.SetItems(items)
+	}
+
 	switch opts.outputFormat {
 	case dump.JSONFormat:
 		data, _ := json.Marshal(response)
@@ -135,8 +200,9 @@ func runList(opts *options) error {
 		data, _ := yaml.Marshal(response)
 		_ = dump.YAML(opts.IO.Out, data)
 	default:
-		rows := mapResponseItemsToRows(response.GetItems())
-		dump.Table(opts.IO.Out, rows)
+		if err = opts.listFlags.Table(opts.IO.Out, rows); err != nil {
+			return err
+		}
 		logger.Info("")
 	}
 
@@ -154,6 +220,12 @@ func mapResponseItemsToRows(kafkas []kafkamgmtclient.KafkaRequest) []kafkaRow {
 			Status:        k.GetStatus(),
 			CloudProvider: k.GetCloudProvider(),
 			Region:        k.GetRegion(),
+			MultiAZ:       k.GetMultiAz(),
+		}
+
+		if createdAt := k.GetCreatedAt(); !createdAt.IsZero() {
+			row.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+			row.Age = createdAt.Unix()
 		}
 
 		rows = append(rows, row)
@@ -0,0 +1,283 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/aerogear/charmil-host-example/pkg/api/kas"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil-host-example/pkg/kafka"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/kafkaerr"
+	kafkametrics "github.com/aerogear/charmil-host-example/pkg/kafka/metrics"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultDuration = 5 * time.Minute
+	defaultStep     = 30 * time.Second
+	// sparklineWidth is the maximum number of bars in the trend column
+	sparklineWidth = 30
+)
+
+var validOutputFormats = []string{dump.JSONFormat, dump.YAMLFormat, dump.YMLFormat, dump.CSVFormat}
+
+type Options struct {
+	id           string
+	name         string
+	metrics      []string
+	duration     time.Duration
+	step         time.Duration
+	instant      bool
+	outputFormat string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// rangeRow is a single series of a range query in the table output
+type rangeRow struct {
+	Metric string `header:"Metric"`
+	Labels string `header:"Labels"`
+	Latest string `header:"Latest"`
+	Min    string `header:"Min"`
+	Max    string `header:"Max"`
+	Trend  string `header:"Trend"`
+}
+
+// instantRow is a single series of an instant query in the table output
+type instantRow struct {
+	Metric string `header:"Metric"`
+	Labels string `header:"Labels"`
+	Value  string `header:"Value"`
+	Time   string `header:"Time"`
+}
+
+// NewMetricsCommand creates a new command to query the metrics of a Kafka instance
+func NewMetricsCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		CfgHandler: f.CfgHandler,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.metrics.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.metrics.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.metrics.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.metrics.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, validOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, validOutputFormats...)
+			}
+
+			if opts.instant && (cmd.Flags().Changed("duration") || cmd.Flags().Changed("step")) {
+				return errors.New(opts.localizer.LocalizeByID("kafka.metrics.error.rangeWithInstant"))
+			}
+
+			if opts.duration < time.Minute {
+				return errors.New(opts.localizer.LocalizeByID("kafka.metrics.error.durationTooShort", localize.NewEntry("Duration", opts.duration)))
+			}
+
+			if opts.step < time.Second || opts.step > opts.duration {
+				return errors.New(opts.localizer.LocalizeByID("kafka.metrics.error.invalidStep", localize.NewEntry("Step", opts.step), localize.NewEntry("Duration", opts.duration)))
+			}
+
+			if opts.name != "" && opts.id != "" {
+				return errors.New(opts.localizer.LocalizeByID("service.error.idAndNameCannotBeUsed"))
+			}
+
+			if opts.id != "" || opts.name != "" {
+				return runMetrics(opts)
+			}
+
+			if !opts.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.error.noKafkaSelected"))
+			}
+
+			opts.id = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runMetrics(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.metrics.flag.id"))
+	cmd.Flags().StringVar(&opts.name, "name", "", opts.localizer.LocalizeByID("kafka.metrics.flag.name"))
+	cmd.Flags().StringSliceVarP(&opts.metrics, "metric", "m", []string{}, opts.localizer.LocalizeByID("kafka.metrics.flag.metric"))
+	cmd.Flags().DurationVar(&opts.duration, "duration", defaultDuration, opts.localizer.LocalizeByID("kafka.metrics.flag.duration"))
+	cmd.Flags().DurationVar(&opts.step, "step", defaultStep, opts.localizer.LocalizeByID("kafka.metrics.flag.step"))
+	cmd.Flags().BoolVar(&opts.instant, "instant", false, opts.localizer.LocalizeByID("kafka.metrics.flag.instant"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.metrics.flag.output"))
+
+	_ = cmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidKafkas(f, toComplete)
+	})
+	flagutil.EnableStaticFlagCompletion(cmd, "metric", kafkametrics.KnownMetrics)
+	flagutil.EnableStaticFlagCompletion(cmd, "output", validOutputFormats)
+
+	return cmd
+}
+
+func runMetrics(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api := conn.API()
+	ctx := context.Background()
+
+	if opts.name != "" {
+		kafkaInstance, _, err := kafka.GetKafkaByName(ctx, api.Kafka(), opts.name)
+		if err != nil {
+			return err
+		}
+		opts.id = kafkaInstance.GetId()
+	}
+
+	var series []kafkametrics.Series
+	var response interface{}
+	if opts.instant {
+		req := api.Kafka().GetMetricsByInstantQuery(ctx, opts.id)
+		if len(opts.metrics) > 0 {
+			req = req.Filters(opts.metrics)
+		}
+
+		res, _, err := req.Execute()
+		if err != nil {
+			return metricsError(err, opts.id)
+		}
+		response = res
+		series = kafkametrics.FromInstantQuery(res.GetItems())
+	} else {
+		req := api.Kafka().GetMetricsByRangeQuery(ctx, opts.id)
+		// the API accepts the duration in minutes and the interval in seconds
+		req = req.Duration(int64(math.Ceil(opts.duration.Minutes())))
+		req = req.Interval(int64(opts.step.Seconds()))
+		if len(opts.metrics) > 0 {
+			req = req.Filters(opts.metrics)
+		}
+
+		res, _, err := req.Execute()
+		if err != nil {
+			return metricsError(err, opts.id)
+		}
+		response = res
+		series = kafkametrics.FromRangeQuery(res.GetItems())
+	}
+
+	if len(series) == 0 && opts.outputFormat == "" {
+		logger.Info(opts.localizer.LocalizeByID("kafka.metrics.log.info.noMetrics"))
+		return nil
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.Marshal(response)
+		return dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(response)
+		return dump.YAML(opts.IO.Out, data)
+	case dump.CSVFormat:
+		return dump.CSV(opts.IO.Out, mapSeriesToRecords(series))
+	default:
+		if opts.instant {
+			dump.Table(opts.IO.Out, mapSeriesToInstantRows(series))
+		} else {
+			dump.Table(opts.IO.Out, mapSeriesToRangeRows(series))
+		}
+	}
+
+	return nil
+}
+
+func metricsError(err error, id string) error {
+	if kas.IsErr(err, kas.ErrorNotFound) {
+		return kafkaerr.NotFoundByIDError(id)
+	}
+	return err
+}
+
+func mapSeriesToRangeRows(series []kafkametrics.Series) []rangeRow {
+	rows := make([]rangeRow, 0, len(series))
+	for i := range series {
+		s := &series[i]
+		min, max := s.MinMax()
+		rows = append(rows, rangeRow{
+			Metric: s.Name,
+			Labels: s.LabelString(),
+			Latest: formatValue(s.Latest()),
+			Min:    formatValue(min),
+			Max:    formatValue(max),
+			Trend:  kafkametrics.Sparkline(s.Values(), sparklineWidth),
+		})
+	}
+	return rows
+}
+
+func mapSeriesToInstantRows(series []kafkametrics.Series) []instantRow {
+	rows := make([]instantRow, 0, len(series))
+	for i := range series {
+		s := &series[i]
+		row := instantRow{
+			Metric: s.Name,
+			Labels: s.LabelString(),
+			Value:  formatValue(s.Latest()),
+		}
+		if len(s.Points) > 0 {
+			row.Time = formatTimestamp(s.Points[0].Timestamp)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// mapSeriesToRecords returns a CSV record for every point of every series
+func mapSeriesToRecords(series []kafkametrics.Series) [][]string {
+	records := [][]string{{"metric", "labels", "timestamp", "value"}}
+	for i := range series {
+		s := &series[i]
+		for _, p := range s.Points {
+			records = append(records, []string{s.Name, s.LabelString(), formatTimestamp(p.Timestamp), strconv.FormatFloat(p.Value, 'f', -1, 64)})
+		}
+	}
+	return records
+}
+
+// formatValue prints whole numbers without decimals and other values with 3 decimals
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.3f", v)
+}
+
+// formatTimestamp formats a timestamp in milliseconds as an RFC 3339 UTC time
+func formatTimestamp(ms int64) string {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
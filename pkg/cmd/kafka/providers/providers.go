@@ -0,0 +1,114 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aerogear/charmil-host-example/pkg/cloudprovider/cloudproviderutil"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	IO         *iostreams.IOStreams
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+
+	outputFormat string
+}
+
+type providerRow struct {
+	Name        string `json:"name" yaml:"name" header:"Name"`
+	DisplayName string `json:"display_name" yaml:"display_name" header:"Display Name"`
+}
+
+// NewProvidersCommand creates a new command to list the cloud providers of Kafka instances
+func NewProvidersCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:         f.IOStreams,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.providers.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.providers.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.providers.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.providers.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			return runProviders(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.providers.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runProviders(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	cloudProviderResponse, _, err := conn.API().Kafka().GetCloudProviders(context.Background()).Execute()
+	if err != nil {
+		return err
+	}
+
+	rows := mapProvidersToRows(cloudproviderutil.FilterEnabled(cloudProviderResponse.GetItems()))
+
+	if len(rows) == 0 && opts.outputFormat == "" {
+		logger.Info(opts.localizer.LocalizeByID("kafka.providers.log.info.noProviders"))
+		return nil
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(rows, "", cmdutil.DefaultJSONIndent)
+		return dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(rows)
+		return dump.YAML(opts.IO.Out, data)
+	default:
+		dump.Table(opts.IO.Out, rows)
+	}
+
+	return nil
+}
+
+func mapProvidersToRows(cloudProviders []kafkamgmtclient.CloudProvider) []providerRow {
+	rows := make([]providerRow, 0, len(cloudProviders))
+	for _, p := range cloudProviders {
+		rows = append(rows, providerRow{
+			Name:        p.GetName(),
+			DisplayName: p.GetDisplayName(),
+		})
+	}
+
+	return rows
+}
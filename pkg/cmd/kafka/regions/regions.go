@@ -0,0 +1,140 @@
+package regions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/cloudprovider/cloudproviderutil"
+	"github.com/aerogear/charmil-host-example/pkg/cloudregion/cloudregionutil"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/flags"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	IO         *iostreams.IOStreams
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+
+	provider     string
+	outputFormat string
+}
+
+type regionRow struct {
+	Provider    string `json:"provider" yaml:"provider" header:"Provider"`
+	ID          string `json:"id" yaml:"id" header:"ID"`
+	DisplayName string `json:"display_name" yaml:"display_name" header:"Display Name"`
+}
+
+// NewRegionsCommand creates a new command to list the cloud regions of Kafka instances
+func NewRegionsCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		IO:         f.IOStreams,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.regions.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.regions.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.regions.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.regions.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.outputFormat != "" && !flagutil.IsValidInput(opts.outputFormat, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.outputFormat, flagutil.ValidOutputFormats...)
+			}
+
+			return runRegions(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.provider, flags.FlagProvider, "", opts.localizer.LocalizeByID("kafka.regions.flag.provider.description"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.regions.flag.output.description"))
+
+	_ = cmd.RegisterFlagCompletionFunc(flags.FlagProvider, func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FetchCloudProviders(f)
+	})
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runRegions(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api := conn.API().Kafka()
+
+	cloudProviderResponse, _, err := api.GetCloudProviders(context.Background()).Execute()
+	if err != nil {
+		return err
+	}
+
+	cloudProviders := cloudproviderutil.FilterEnabled(cloudProviderResponse.GetItems())
+
+	if opts.provider != "" {
+		selectedCloudProvider := cloudproviderutil.FindByName(cloudProviders, opts.provider)
+		if selectedCloudProvider == nil {
+			providerNames := cloudproviderutil.GetEnabledNames(cloudProviders)
+			return errors.New(opts.localizer.LocalizeByID("kafka.validation.error.invalidProvider", localize.NewEntry("Provider", opts.provider), localize.NewEntry("ValidProviders", strings.Join(providerNames, ", "))))
+		}
+		cloudProviders = []kafkamgmtclient.CloudProvider{*selectedCloudProvider}
+	}
+
+	rows := []regionRow{}
+	for _, p := range cloudProviders {
+		cloudRegionResponse, _, err := api.GetCloudProviderRegions(context.Background(), p.GetId()).Execute()
+		if err != nil {
+			return err
+		}
+
+		for _, r := range cloudregionutil.FilterEnabled(cloudRegionResponse.GetItems()) {
+			rows = append(rows, regionRow{
+				Provider:    p.GetName(),
+				ID:          r.GetId(),
+				DisplayName: r.GetDisplayName(),
+			})
+		}
+	}
+
+	if len(rows) == 0 && opts.outputFormat == "" {
+		logger.Info(opts.localizer.LocalizeByID("kafka.regions.log.info.noRegions"))
+		return nil
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(rows, "", cmdutil.DefaultJSONIndent)
+		return dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(rows)
+		return dump.YAML(opts.IO.Out, data)
+	default:
+		dump.Table(opts.IO.Out, rows)
+	}
+
+	return nil
+}
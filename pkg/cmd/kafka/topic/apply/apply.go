@@ -0,0 +1,229 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	file         string
+	kafkaID      string
+	prune        bool
+	dryRun       bool
+	force        bool
+	outputFormat string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+type changeRow struct {
+	Action  string `json:"action" header:"Action"`
+	Topic   string `json:"topic" header:"Topic"`
+	Details string `json:"details" header:"Details"`
+}
+
+// NewApplyTopicCommand gets a new command for applying a manifest of kafka topics.
+func NewApplyTopicCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.topic.apply.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.topic.apply.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.topic.apply.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.topic.apply.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if opts.outputFormat != "" {
+				if err = flag.ValidateOutput(opts.outputFormat); err != nil {
+					return err
+				}
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", opts.localizer.LocalizeByID("kafka.topic.apply.flag.file.description"))
+	cmd.Flags().BoolVar(&opts.prune, "prune", false, opts.localizer.LocalizeByID("kafka.topic.apply.flag.prune.description"))
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, opts.localizer.LocalizeByID("kafka.topic.apply.flag.dryRun.description"))
+	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.LocalizeByID("kafka.topic.apply.flag.yes.description"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.topic.apply.flag.output.description"))
+
+	_ = cmd.MarkFlagRequired("file")
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	manifest, err := readManifest(opts)
+	if err != nil {
+		return err
+	}
+
+	validator := &topicutil.Validator{
+		Localizer:    opts.localizer,
+		NamingPolicy: opts.CfgHandler.Cfg.TopicNamingPolicy,
+	}
+
+	if err = validator.ValidateManifest(manifest); err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	live, httpRes, err := topicutil.FetchAllTopics(ctx, api, "")
+	if err != nil {
+		return topicutil.APIError(opts.localizer, httpRes, err, "", kafkaInstance.GetName(), "list")
+	}
+
+	plan, err := validator.NewPlan(manifest, live, opts.prune)
+	if err != nil {
+		return err
+	}
+
+	if plan.IsEmpty() {
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.apply.log.info.noChanges", kafkaNameTmplPair))
+		return nil
+	}
+
+	printPlan(opts, plan)
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.topic.apply.log.info.planSummary",
+		localize.NewEntry("Create", plan.Count(topicutil.ActionCreate)),
+		localize.NewEntry("Update", plan.Count(topicutil.ActionUpdate)),
+		localize.NewEntry("Delete", plan.Count(topicutil.ActionDelete)),
+	))
+
+	if opts.dryRun {
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.apply.log.info.dryRun"))
+		return nil
+	}
+
+	// deleting topics loses their messages, so it must be confirmed
+	if plan.Count(topicutil.ActionDelete) > 0 && !opts.force {
+		if !opts.IO.CanPrompt() {
+			return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
+		}
+
+		var confirmed bool
+		promptConfirm := &survey.Confirm{
+			Message: opts.localizer.LocalizeByID("kafka.topic.apply.input.confirmDelete.message", localize.NewEntry("Count", plan.Count(topicutil.ActionDelete)), kafkaNameTmplPair),
+		}
+		if err = survey.AskOne(promptConfirm, &confirmed); err != nil {
+			return err
+		}
+		if !confirmed {
+			logger.Infoln(opts.localizer.LocalizeByID("kafka.topic.apply.log.debug.notConfirmed"))
+			return nil
+		}
+	}
+
+	for _, change := range plan.Changes {
+		if httpRes, err = topicutil.ApplyChange(ctx, api, change); err != nil {
+			return topicutil.APIError(opts.localizer, httpRes, err, change.Topic, kafkaInstance.GetName(), string(change.Action))
+		}
+
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.apply.log.info.changeApplied",
+			localize.NewEntry("Action", change.Action), localize.NewEntry("TopicName", change.Topic)))
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.topic.apply.log.info.applied", kafkaNameTmplPair))
+
+	return nil
+}
+
+// readManifest reads the topic manifest from --file, or from standard input when it is "-"
+func readManifest(opts *Options) (*topicutil.Manifest, error) {
+	var r io.Reader
+	if opts.file == "-" {
+		r = opts.IO.In
+	} else {
+		f, err := os.Open(opts.file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	manifest, err := topicutil.ReadManifest(r)
+	if err != nil {
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.topic.apply.error.invalidFile", localize.NewEntry("File", opts.file), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	return manifest, nil
+}
+
+func printPlan(opts *Options, plan *topicutil.Plan) {
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.Marshal(plan)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(plan)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		rows := make([]changeRow, len(plan.Changes))
+		for i := range plan.Changes {
+			change := &plan.Changes[i]
+			rows[i] = changeRow{
+				Action:  string(change.Action),
+				Topic:   change.Topic,
+				Details: change.Summary(),
+			}
+		}
+		dump.Table(opts.IO.Out, rows)
+	}
+}
@@ -0,0 +1,185 @@
+package consume
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	topicName       string
+	kafkaID         string
+	partition       int
+	offset          string
+	timestamp       string
+	maxMessages     int
+	format          string
+	keySeparator    string
+	credentialsFile string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewConsumeTopicCommand gets a new command for consuming messages from a kafka topic.
+func NewConsumeTopicCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.topic.consume.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.topic.consume.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.topic.consume.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.topic.consume.cmd.example"),
+		Args:    cobra.ExactValidArgs(1),
+		// dynamic completion of topic names
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cmdutil.FilterValidTopicNameArgs(f, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.topicName = args[0]
+
+			if !flagutil.IsValidInput(opts.format, messaging.ValidFormats...) {
+				return flag.InvalidValueError("format", opts.format, messaging.ValidFormats...)
+			}
+
+			if opts.partition < messaging.AnyPartition {
+				return flag.InvalidValueError("partition", opts.partition)
+			}
+
+			if opts.maxMessages < 0 {
+				return flag.InvalidValueError("max-messages", opts.maxMessages)
+			}
+
+			if cmd.Flags().Changed("offset") && opts.timestamp != "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.consume.error.offsetAndTimestamp"))
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.partition, "partition", messaging.AnyPartition, opts.localizer.LocalizeByID("kafka.topic.consume.flag.partition.description"))
+	cmd.Flags().StringVar(&opts.offset, "offset", messaging.OffsetLatest, opts.localizer.LocalizeByID("kafka.topic.consume.flag.offset.description"))
+	cmd.Flags().StringVar(&opts.timestamp, "timestamp", "", opts.localizer.LocalizeByID("kafka.topic.consume.flag.timestamp.description"))
+	cmd.Flags().IntVar(&opts.maxMessages, "max-messages", 0, opts.localizer.LocalizeByID("kafka.topic.consume.flag.maxMessages.description"))
+	cmd.Flags().StringVar(&opts.format, "format", messaging.FormatRaw, opts.localizer.LocalizeByID("kafka.topic.consume.flag.format.description"))
+	cmd.Flags().StringVar(&opts.keySeparator, "key-separator", "", opts.localizer.LocalizeByID("kafka.topic.consume.flag.keySeparator.description"))
+	cmd.Flags().StringVar(&opts.credentialsFile, "credentials-file", "", opts.localizer.LocalizeByID("kafka.topic.common.flag.credentialsFile.description"))
+
+	flagutil.EnableStaticFlagCompletion(cmd, "format", messaging.ValidFormats)
+	flagutil.EnableStaticFlagCompletion(cmd, "offset", []string{messaging.OffsetEarliest, messaging.OffsetLatest})
+
+	return cmd
+}
+
+func runCmd(opts *Options) error {
+	consumeOpts := messaging.ConsumeOptions{
+		Topic:       opts.topicName,
+		MaxMessages: opts.maxMessages,
+	}
+
+	if opts.partition != messaging.AnyPartition {
+		consumeOpts.Partitions = []int{opts.partition}
+	}
+
+	if opts.timestamp != "" {
+		t, err := parseTimestamp(opts.timestamp)
+		if err != nil {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.consume.error.invalidTimestamp", localize.NewEntry("Timestamp", opts.timestamp)))
+		}
+		consumeOpts.Timestamp = t
+	} else {
+		offset, err := messaging.ParseOffset(opts.offset)
+		if err != nil {
+			return flag.InvalidValueError("offset", opts.offset, messaging.OffsetEarliest, messaging.OffsetLatest)
+		}
+		consumeOpts.Offset = offset
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	_, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := messaging.NewTokenSource(opts.CfgHandler.Cfg, opts.credentialsFile)
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.invalidCredentialsFile", localize.NewEntry("File", opts.credentialsFile), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	cfg, err := messaging.ConfigForKafka(kafkaInstance, tokens, opts.CfgHandler.Cfg.Insecure)
+	if err != nil {
+		return err
+	}
+
+	client := messaging.NewClient(cfg)
+	defer client.Close()
+
+	// consuming stops gracefully when interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger.Infoln(opts.localizer.LocalizeByID("kafka.topic.consume.log.debug.consuming", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+
+	outputOpts := messaging.OutputOptions{
+		Format:       opts.format,
+		KeySeparator: opts.keySeparator,
+	}
+
+	err = client.Consume(ctx, consumeOpts, func(msg messaging.Message) error {
+		return messaging.WriteMessage(opts.IO.Out, msg, outputOpts)
+	})
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.consume.error.consumeFailed", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName()), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	return nil
+}
+
+// parseTimestamp parses a timestamp in the RFC 3339 format or as milliseconds since the Unix epoch
+func parseTimestamp(value string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,169 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
+	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	topicName    string
+	kafkaID      string
+	outputFormat string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+type consumerRow struct {
+	ConsumerGroupID      string `json:"groupId" header:"Consumer group ID"`
+	ActiveMembers        int    `json:"activeMembers" header:"Active members"`
+	TotalLag             int    `json:"totalLag" header:"Total lag"`
+	PartitionsWithLag    int    `json:"partitionsWithLag" header:"Partitions with lag"`
+	UnconsumedPartitions int    `json:"unconsumedPartitions" header:"Unconsumed partitions"`
+}
+
+// NewConsumersTopicCommand gets a new command for listing the consumer groups of a kafka topic.
+func NewConsumersTopicCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.topic.consumers.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.topic.consumers.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.topic.consumers.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.topic.consumers.cmd.example"),
+		Args:    cobra.ExactValidArgs(1),
+		// dynamic completion of topic names
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cmdutil.FilterValidTopicNameArgs(f, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			opts.topicName = args[0]
+
+			if opts.outputFormat != "" {
+				if err = flag.ValidateOutput(opts.outputFormat); err != nil {
+					return err
+				}
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.topic.consumers.flag.output.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runCmd(opts *Options) error {
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	// the topic is fetched first so that a missing topic is not reported as a topic without consumers
+	_, httpRes, err := api.TopicsApi.GetTopic(ctx, opts.topicName).Execute()
+	if err != nil {
+		if httpRes != nil && httpRes.StatusCode == 404 {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.topicNotFoundError", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+		}
+		return topicutil.APIError(opts.localizer, httpRes, err, opts.topicName, kafkaInstance.GetName(), "view")
+	}
+
+	groups, httpRes, err := consumergroup.FetchAllForTopic(ctx, api, opts.topicName)
+	if err != nil {
+		return topicutil.APIError(opts.localizer, httpRes, err, opts.topicName, kafkaInstance.GetName(), "view")
+	}
+
+	rows := mapConsumerGroupsToRows(groups, opts.topicName)
+
+	if len(rows) == 0 && opts.outputFormat == "" {
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.consumers.log.info.noConsumers", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+		return nil
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.Marshal(rows)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(rows)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		dump.Table(opts.IO.Out, rows)
+	}
+
+	return nil
+}
+
+// mapConsumerGroupsToRows summarises the consumers of each consumer group which read from the topic
+func mapConsumerGroupsToRows(groups []kafkainstanceclient.ConsumerGroup, topicName string) []consumerRow {
+	rows := []consumerRow{}
+
+	for _, group := range groups {
+		consumers := consumergroup.FilterConsumersByTopic(group.GetConsumers(), topicName)
+		if len(consumers) == 0 {
+			continue
+		}
+
+		rows = append(rows, consumerRow{
+			ConsumerGroupID:      group.GetGroupId(),
+			ActiveMembers:        consumergroup.GetActiveConsumersCount(consumergroup.FilterConsumersWithMember(consumers)),
+			TotalLag:             consumergroup.GetTotalLag(consumers),
+			PartitionsWithLag:    consumergroup.GetPartitionsWithLag(consumers),
+			UnconsumedPartitions: consumergroup.GetUnconsumedPartitions(consumers),
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].ConsumerGroupID < rows[j].ConsumerGroupID
+	})
+
+	return rows
+}
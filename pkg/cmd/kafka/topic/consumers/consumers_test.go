@@ -0,0 +1,68 @@
+package consumers
+
+import (
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestMapConsumerGroupsToRows(t *testing.T) {
+	tests := []struct {
+		name          string
+		consumers     []kafkainstanceclient.Consumer
+		wantRows      int
+		wantActive    int
+		wantUnclaimed int
+	}{
+		{
+			name: "Should count the partitions consumed by members",
+			consumers: []kafkainstanceclient.Consumer{
+				mockutil.NewConsumerMock("orders", 0, "member-1", 0),
+				mockutil.NewConsumerMock("orders", 1, "member-2", 0),
+				mockutil.NewConsumerMock("payments", 0, "member-1", 0),
+			},
+			wantRows:   1,
+			wantActive: 2,
+		},
+		{
+			name: "Should not count committed offsets without a member as active",
+			consumers: []kafkainstanceclient.Consumer{
+				mockutil.NewConsumerMock("orders", 0, "", 0),
+				mockutil.NewConsumerMock("orders", 1, "", 0),
+			},
+			wantRows:      1,
+			wantActive:    0,
+			wantUnclaimed: 2,
+		},
+		{
+			name: "Should skip groups which do not consume the topic",
+			consumers: []kafkainstanceclient.Consumer{
+				mockutil.NewConsumerMock("payments", 0, "member-1", 0),
+			},
+			wantRows: 0,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			group := kafkainstanceclient.ConsumerGroup{}
+			group.SetGroupId("group-1")
+			group.SetConsumers(tt.consumers)
+
+			rows := mapConsumerGroupsToRows([]kafkainstanceclient.ConsumerGroup{group}, "orders")
+			if len(rows) != tt.wantRows {
+				t.Fatalf("mapConsumerGroupsToRows() returned %v rows, want %v", len(rows), tt.wantRows)
+			}
+			if tt.wantRows == 0 {
+				return
+			}
+			if rows[0].ActiveMembers != tt.wantActive {
+				t.Errorf("ActiveMembers = %v, want %v", rows[0].ActiveMembers, tt.wantActive)
+			}
+			if rows[0].UnconsumedPartitions != tt.wantUnclaimed {
+				t.Errorf("UnconsumedPartitions = %v, want %v", rows[0].UnconsumedPartitions, tt.wantUnclaimed)
+			}
+		})
+	}
+}
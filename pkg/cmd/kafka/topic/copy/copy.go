@@ -0,0 +1,334 @@
+package copy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil-host-example/pkg/kafka"
+	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	conflictSkip   = "skip"
+	conflictUpdate = "update"
+	conflictFail   = "fail"
+)
+
+var validConflictPolicies = []string{conflictSkip, conflictUpdate, conflictFail}
+
+const (
+	resultCreated   = "created"
+	resultUpdated   = "updated"
+	resultUnchanged = "unchanged"
+	resultSkipped   = "skipped"
+	resultFailed    = "failed"
+)
+
+type Options struct {
+	from         string
+	to           string
+	topicNames   []string
+	pattern      string
+	regex        bool
+	onConflict   string
+	outputFormat string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+type resultRow struct {
+	Topic   string `json:"topic" header:"Topic"`
+	Result  string `json:"result" header:"Result"`
+	Details string `json:"details,omitempty" header:"Details"`
+}
+
+// NewCopyTopicCommand gets a new command for copying kafka topics between instances.
+func NewCopyTopicCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.topic.copy.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.topic.copy.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.topic.copy.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.topic.copy.cmd.example"),
+		Args:    cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			opts.topicNames = args
+
+			if opts.outputFormat != "" {
+				if err = flag.ValidateOutput(opts.outputFormat); err != nil {
+					return err
+				}
+			}
+
+			if !flagutil.IsValidInput(opts.onConflict, validConflictPolicies...) {
+				return flag.InvalidValueError("on-conflict", opts.onConflict, validConflictPolicies...)
+			}
+
+			if len(opts.topicNames) == 0 && opts.pattern == "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.copy.error.namesOrPatternRequired"))
+			}
+
+			if len(opts.topicNames) > 0 && opts.pattern != "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.copy.error.namesAndPattern"))
+			}
+
+			if opts.regex && opts.pattern == "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.regexWithoutPattern"))
+			}
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", opts.localizer.LocalizeByID("kafka.topic.copy.flag.from.description"))
+	cmd.Flags().StringVar(&opts.to, "to", "", opts.localizer.LocalizeByID("kafka.topic.copy.flag.to.description"))
+	cmd.Flags().StringVar(&opts.pattern, "pattern", "", opts.localizer.LocalizeByID("kafka.topic.common.flag.pattern.description"))
+	cmd.Flags().BoolVar(&opts.regex, "regex", false, opts.localizer.LocalizeByID("kafka.topic.common.flag.regex.description"))
+	cmd.Flags().StringVar(&opts.onConflict, "on-conflict", conflictFail, opts.localizer.LocalizeByID("kafka.topic.copy.flag.onConflict.description"))
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "", opts.localizer.LocalizeByID("kafka.topic.copy.flag.output.description"))
+
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	for _, flagName := range []string{"from", "to"} {
+		_ = cmd.RegisterFlagCompletionFunc(flagName, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cmdutil.FilterValidKafkas(f, toComplete)
+		})
+	}
+
+	flagutil.EnableStaticFlagCompletion(cmd, "on-conflict", validConflictPolicies)
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	var pattern *topicutil.NamePattern
+	if opts.pattern != "" {
+		var err error
+		if pattern, err = topicutil.NewNamePattern(opts.pattern, opts.regex); err != nil {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.invalidPattern", localize.NewEntry("Pattern", opts.pattern), localize.NewEntry("Error", err)))
+		}
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	source, _, err := kafka.GetKafkaByIDOrName(ctx, conn.API().Kafka(), opts.from)
+	if err != nil {
+		return err
+	}
+
+	target, _, err := kafka.GetKafkaByIDOrName(ctx, conn.API().Kafka(), opts.to)
+	if err != nil {
+		return err
+	}
+
+	if source.GetId() == target.GetId() {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.copy.error.sameInstance"))
+	}
+
+	sourceAPI, _, err := conn.API().KafkaAdmin(source.GetId())
+	if err != nil {
+		return err
+	}
+
+	targetAPI, _, err := conn.API().KafkaAdmin(target.GetId())
+	if err != nil {
+		return err
+	}
+
+	specs, err := fetchSourceSpecs(ctx, opts, sourceAPI, source.GetName(), pattern)
+	if err != nil {
+		return err
+	}
+
+	if len(specs) == 0 {
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.copy.log.info.noTopics", localize.NewEntry("InstanceName", source.GetName())))
+		return nil
+	}
+
+	live, httpRes, err := topicutil.FetchAllTopics(ctx, targetAPI, "")
+	if err != nil {
+		return topicutil.APIError(opts.localizer, httpRes, err, "", target.GetName(), "list")
+	}
+
+	// updating an existing target topic resets the config entries which only the target overrides
+	existing := map[string]bool{}
+	for _, t := range live {
+		existing[t.GetName()] = true
+		for i := range specs {
+			if specs[i].Name == t.GetName() {
+				topicutil.ResetOverriddenConfig(&specs[i], t)
+			}
+		}
+	}
+
+	// with the fail policy, nothing is copied when any topic already exists
+	if opts.onConflict == conflictFail {
+		conflicts := []string{}
+		for _, spec := range specs {
+			if existing[spec.Name] {
+				conflicts = append(conflicts, spec.Name)
+			}
+		}
+		if len(conflicts) > 0 {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.copy.error.conflict",
+				localize.NewEntry("Topics", strings.Join(conflicts, ", ")), localize.NewEntry("InstanceName", target.GetName())))
+		}
+	}
+
+	validator := &topicutil.Validator{
+		Localizer:    opts.localizer,
+		NamingPolicy: opts.CfgHandler.Cfg.TopicNamingPolicy,
+	}
+
+	rows := make([]resultRow, 0, len(specs))
+	failed := 0
+	for _, spec := range specs {
+		row := resultRow{Topic: spec.Name}
+
+		if existing[spec.Name] && opts.onConflict == conflictSkip {
+			row.Result = resultSkipped
+			rows = append(rows, row)
+			continue
+		}
+
+		plan, err := validator.NewPlan(&topicutil.Manifest{Topics: []topicutil.TopicSpec{spec}}, live, false)
+		if err != nil {
+			row.Result, row.Details = resultFailed, err.Error()
+			failed++
+			rows = append(rows, row)
+			continue
+		}
+
+		if plan.IsEmpty() {
+			row.Result = resultUnchanged
+			rows = append(rows, row)
+			continue
+		}
+
+		change := plan.Changes[0]
+		row.Details = change.Summary()
+		if httpRes, err = topicutil.ApplyChange(ctx, targetAPI, change); err != nil {
+			row.Result, row.Details = resultFailed, topicutil.APIError(opts.localizer, httpRes, err, spec.Name, target.GetName(), string(change.Action)).Error()
+			failed++
+		} else if change.Action == topicutil.ActionCreate {
+			row.Result = resultCreated
+		} else {
+			row.Result = resultUpdated
+		}
+		rows = append(rows, row)
+	}
+
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, _ := json.Marshal(rows)
+		_ = dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(rows)
+		_ = dump.YAML(opts.IO.Out, data)
+	default:
+		dump.Table(opts.IO.Out, rows)
+	}
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row.Result]++
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.topic.copy.log.info.summary",
+		localize.NewEntry("Source", source.GetName()),
+		localize.NewEntry("Target", target.GetName()),
+		localize.NewEntry("Created", counts[resultCreated]),
+		localize.NewEntry("Updated", counts[resultUpdated]),
+		localize.NewEntry("Unchanged", counts[resultUnchanged]),
+		localize.NewEntry("Skipped", counts[resultSkipped]),
+		localize.NewEntry("Failed", counts[resultFailed]),
+	))
+
+	if failed > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.copy.error.failed", localize.NewEntry("Count", failed)))
+	}
+
+	return nil
+}
+
+// fetchSourceSpecs reads the definitions of the topics to copy from the source instance,
+// either the topics given by name or the non-internal topics matching the pattern.
+// Only the config entries which differ from their default value are kept.
+func fetchSourceSpecs(ctx context.Context, opts *Options, api *kafkainstanceclient.APIClient, instanceName string, pattern *topicutil.NamePattern) ([]topicutil.TopicSpec, error) {
+	specs := []topicutil.TopicSpec{}
+
+	if pattern == nil {
+		seen := map[string]bool{}
+		for _, name := range opts.topicNames {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			topic, httpRes, err := api.TopicsApi.GetTopic(ctx, name).Execute()
+			if err != nil {
+				return nil, topicutil.APIError(opts.localizer, httpRes, err, name, instanceName, "view")
+			}
+			specs = append(specs, topicutil.NewTopicSpec(topic))
+		}
+		return specs, nil
+	}
+
+	topics, httpRes, err := topicutil.FetchAllTopics(ctx, api, "")
+	if err != nil {
+		return nil, topicutil.APIError(opts.localizer, httpRes, err, "", instanceName, "list")
+	}
+
+	for _, topic := range topics {
+		if topicutil.IsInternalTopic(topic.GetName()) || !pattern.Match(topic.GetName()) {
+			continue
+		}
+		specs = append(specs, topicutil.NewTopicSpec(topic))
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		return specs[i].Name < specs[j].Name
+	})
+
+	return specs, nil
+}
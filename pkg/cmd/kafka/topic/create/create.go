@@ -36,14 +36,19 @@ const (
 )
 
 type Options struct {
-	topicName      string
-	partitions     int32
-	retentionMs    int
-	retentionBytes int
-	kafkaID        string
-	outputFormat   string
-	cleanupPolicy  string
-	interactive    bool
+	topicName         string
+	partitions        int32
+	retentionMs       int
+	retentionBytes    int
+	retentionMsStr    string
+	retentionBytesStr string
+	kafkaID           string
+	outputFormat      string
+	cleanupPolicy     string
+	configPairs       []string
+	configFile        string
+	configEntries     map[string]string
+	interactive       bool
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -85,10 +90,20 @@ func NewCreateTopicCommand(f *factory.Factory) *cobra.Command {
 				return flag.InvalidValueError("cleanup-policy", opts.cleanupPolicy, topicutil.ValidCleanupPolicies...)
 			}
 
+			opts.configEntries, err = topicutil.LoadConfigEntries(opts.localizer, opts.configPairs, opts.configFile)
+			if err != nil {
+				return err
+			}
+
+			if err = topicutil.ValidateConfigFlagConflicts(opts.localizer, opts.configEntries, cmd.Flags().Changed); err != nil {
+				return err
+			}
+
 			if !opts.interactive {
 
 				validator := topicutil.Validator{
-					Localizer: opts.localizer,
+					Localizer:    opts.localizer,
+					NamingPolicy: opts.CfgHandler.Cfg.TopicNamingPolicy,
 				}
 
 				opts.topicName = args[0]
@@ -97,15 +112,27 @@ func NewCreateTopicCommand(f *factory.Factory) *cobra.Command {
 					return err
 				}
 
+				if err = validator.ValidateNamingPolicy(opts.topicName); err != nil {
+					return err
+				}
+
 				if err = validator.ValidatePartitionsN(opts.partitions); err != nil {
 					return err
 				}
 
-				if err = validator.ValidateMessageRetentionPeriod(opts.retentionMs); err != nil {
+				if err = validator.ValidateMessageRetentionPeriod(opts.retentionMsStr); err != nil {
+					return err
+				}
+
+				if err = validator.ValidateMessageRetentionSize(opts.retentionBytesStr); err != nil {
+					return err
+				}
+
+				if opts.retentionMs, err = topicutil.ConvertRetentionMsToInt(opts.retentionMsStr); err != nil {
 					return err
 				}
 
-				if err = validator.ValidateMessageRetentionSize(opts.retentionBytes); err != nil {
+				if opts.retentionBytes, err = topicutil.ConvertRetentionBytesToInt(opts.retentionBytesStr); err != nil {
 					return err
 				}
 			}
@@ -126,9 +153,11 @@ func NewCreateTopicCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "json", opts.localizer.LocalizeByID("kafka.topic.common.flag.output.description"))
 	cmd.Flags().Int32Var(&opts.partitions, "partitions", 1, opts.localizer.LocalizeByID("kafka.topic.common.input.partitions.description"))
-	cmd.Flags().IntVar(&opts.retentionMs, "retention-ms", defaultRetentionPeriodMS, opts.localizer.LocalizeByID("kafka.topic.common.input.retentionMs.description"))
-	cmd.Flags().IntVar(&opts.retentionBytes, "retention-bytes", defaultRetentionSize, opts.localizer.LocalizeByID("kafka.topic.common.input.retentionBytes.description"))
+	cmd.Flags().StringVar(&opts.retentionMsStr, "retention-ms", strconv.Itoa(defaultRetentionPeriodMS), opts.localizer.LocalizeByID("kafka.topic.common.input.retentionMs.description"))
+	cmd.Flags().StringVar(&opts.retentionBytesStr, "retention-bytes", strconv.Itoa(defaultRetentionSize), opts.localizer.LocalizeByID("kafka.topic.common.input.retentionBytes.description"))
 	cmd.Flags().StringVar(&opts.cleanupPolicy, "cleanup-policy", defaultCleanupPolicy, opts.localizer.LocalizeByID("kafka.topic.common.input.cleanupPolicy.description"))
+	cmd.Flags().StringArrayVar(&opts.configPairs, "config", []string{}, opts.localizer.LocalizeByID("kafka.topic.common.flag.config.description"))
+	cmd.Flags().StringVar(&opts.configFile, "config-file", "", opts.localizer.LocalizeByID("kafka.topic.common.flag.configFile.description"))
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -218,9 +247,10 @@ func runInteractivePrompt(opts *Options) (err error) {
 	}
 
 	validator := topicutil.Validator{
-		Localizer:  opts.localizer,
-		InstanceID: opts.kafkaID,
-		Connection: opts.Connection,
+		Localizer:    opts.localizer,
+		InstanceID:   opts.kafkaID,
+		Connection:   opts.Connection,
+		NamingPolicy: opts.CfgHandler.Cfg.TopicNamingPolicy,
 	}
 
 	logger.Infoln(opts.localizer.LocalizeByID("common.log.debug.startingInteractivePrompt"))
@@ -235,6 +265,7 @@ func runInteractivePrompt(opts *Options) (err error) {
 		&opts.topicName,
 		survey.WithValidator(survey.Required),
 		survey.WithValidator(validator.ValidateName),
+		survey.WithValidator(validator.ValidateNamingPolicy),
 		survey.WithValidator(validator.ValidateNameIsAvailable),
 	)
 
@@ -253,51 +284,64 @@ func runInteractivePrompt(opts *Options) (err error) {
 		return err
 	}
 
-	retentionMsPrompt := &survey.Input{
-		Message: opts.localizer.LocalizeByID("kafka.topic.create.input.retentionMs.message"),
-		Help:    opts.localizer.LocalizeByID("kafka.topic.common.input.retentionMs.description"),
-		Default: strconv.Itoa(defaultRetentionPeriodMS),
-	}
+	// config entries set with --config or --config-file are not prompted for
+	if _, ok := opts.configEntries[topicutil.RetentionMsKey]; !ok {
+		retentionMsPrompt := &survey.Input{
+			Message: opts.localizer.LocalizeByID("kafka.topic.create.input.retentionMs.message"),
+			Help:    opts.localizer.LocalizeByID("kafka.topic.common.input.retentionMs.description"),
+			Default: strconv.Itoa(defaultRetentionPeriodMS),
+		}
 
-	err = survey.AskOne(retentionMsPrompt, &opts.retentionMs, survey.WithValidator(validator.ValidateMessageRetentionPeriod))
-	if err != nil {
-		return err
-	}
+		err = survey.AskOne(retentionMsPrompt, &opts.retentionMsStr, survey.WithValidator(validator.ValidateMessageRetentionPeriod))
+		if err != nil {
+			return err
+		}
 
-	retentionBytesPrompt := &survey.Input{
-		Message: opts.localizer.LocalizeByID("kafka.topic.create.input.retentionBytes.message"),
-		Help:    opts.localizer.LocalizeByID("kafka.topic.common.input.retentionBytes.description"),
-		Default: strconv.Itoa(defaultRetentionSize),
+		if opts.retentionMs, err = topicutil.ConvertRetentionMsToInt(opts.retentionMsStr); err != nil {
+			return err
+		}
 	}
 
-	err = survey.AskOne(retentionBytesPrompt, &opts.retentionBytes, survey.WithValidator(validator.ValidateMessageRetentionSize))
-	if err != nil {
-		return err
-	}
+	if _, ok := opts.configEntries[topicutil.RetentionSizeKey]; !ok {
+		retentionBytesPrompt := &survey.Input{
+			Message: opts.localizer.LocalizeByID("kafka.topic.create.input.retentionBytes.message"),
+			Help:    opts.localizer.LocalizeByID("kafka.topic.common.input.retentionBytes.description"),
+			Default: strconv.Itoa(defaultRetentionSize),
+		}
+
+		err = survey.AskOne(retentionBytesPrompt, &opts.retentionBytesStr, survey.WithValidator(validator.ValidateMessageRetentionSize))
+		if err != nil {
+			return err
+		}
 
-	cleanupPolicyPrompt := &survey.Select{
-		Message: opts.localizer.LocalizeByID("kafka.topic.create.input.cleanupPolicy.message"),
-		Help:    opts.localizer.LocalizeByID("kafka.topic.common.input.cleanupPolicy.description"),
-		Options: topicutil.ValidCleanupPolicies,
-		Default: defaultCleanupPolicy,
+		if opts.retentionBytes, err = topicutil.ConvertRetentionBytesToInt(opts.retentionBytesStr); err != nil {
+			return err
+		}
 	}
 
-	err = survey.AskOne(cleanupPolicyPrompt, &opts.cleanupPolicy)
-	if err != nil {
-		return err
+	if _, ok := opts.configEntries[topicutil.CleanupPolicy]; !ok {
+		cleanupPolicyPrompt := &survey.Select{
+			Message: opts.localizer.LocalizeByID("kafka.topic.create.input.cleanupPolicy.message"),
+			Help:    opts.localizer.LocalizeByID("kafka.topic.common.input.cleanupPolicy.description"),
+			Options: topicutil.ValidCleanupPolicies,
+			Default: defaultCleanupPolicy,
+		}
+
+		err = survey.AskOne(cleanupPolicyPrompt, &opts.cleanupPolicy)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 func createConfigEntries(opts *Options) *[]kafkainstanceclient.ConfigEntry {
-	retentionMsStr := strconv.Itoa(opts.retentionMs)
-	retentionBytesStr := strconv.Itoa(opts.retentionBytes)
-	cleanupPolicyStr := opts.cleanupPolicy
-	configEntryMap := map[string]*string{
-		topicutil.RetentionMsKey:   &retentionMsStr,
-		topicutil.RetentionSizeKey: &retentionBytesStr,
-		topicutil.CleanupPolicy:    &cleanupPolicyStr,
-	}
+	// values from --config and --config-file replace the defaults of the dedicated flags
+	configEntryMap := topicutil.ToConfigEntryMap(topicutil.MergeConfigEntries(map[string]string{
+		topicutil.RetentionMsKey:   strconv.Itoa(opts.retentionMs),
+		topicutil.RetentionSizeKey: strconv.Itoa(opts.retentionBytes),
+		topicutil.CleanupPolicy:    opts.cleanupPolicy,
+	}, opts.configEntries))
 	return topicutil.CreateConfigEntries(configEntryMap)
 }
@@ -3,11 +3,18 @@ package delete
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/parallel"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/consumergroup"
+	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
 	"github.com/aerogear/charmil/core/utils/localize"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
 
 	"github.com/aerogear/charmil/core/utils/iostreams"
 
@@ -20,9 +27,12 @@ import (
 )
 
 type Options struct {
-	topicName string
-	kafkaID   string
-	force     bool
+	topicNames  []string
+	pattern     string
+	regex       bool
+	allMatching bool
+	kafkaID     string
+	force       bool
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -31,7 +41,12 @@ type Options struct {
 	localizer  localize.Localizer
 }
 
-// NewDeleteTopicCommand gets a new command for deleting a kafka topic.
+type topicRow struct {
+	Name       string `json:"name" header:"Name"`
+	Partitions int    `json:"partitions" header:"Partitions"`
+}
+
+// NewDeleteTopicCommand gets a new command for deleting kafka topics.
 func NewDeleteTopicCommand(f *factory.Factory) *cobra.Command {
 	opts := &Options{
 		Connection: f.Connection,
@@ -46,7 +61,7 @@ func NewDeleteTopicCommand(f *factory.Factory) *cobra.Command {
 		Short:   opts.localizer.LocalizeByID("kafka.topic.delete.cmd.shortDescription"),
 		Long:    opts.localizer.LocalizeByID("kafka.topic.delete.cmd.longDescription"),
 		Example: opts.localizer.LocalizeByID("kafka.topic.delete.cmd.example"),
-		Args:    cobra.ExactValidArgs(1),
+		Args:    cobra.ArbitraryArgs,
 		// Dynamic completion of the topic name
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			return cmdutil.FilterValidTopicNameArgs(f, toComplete)
@@ -56,8 +71,22 @@ func NewDeleteTopicCommand(f *factory.Factory) *cobra.Command {
 				return errors.New(opts.localizer.LocalizeByID("flag.error.requiredWhenNonInteractive", localize.NewEntry("Flag", "yes")))
 			}
 
-			if len(args) > 0 {
-				opts.topicName = args[0]
+			opts.topicNames = args
+
+			if len(opts.topicNames) == 0 && opts.pattern == "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.namesOrPatternRequired"))
+			}
+
+			if len(opts.topicNames) > 0 && opts.pattern != "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.namesAndPattern"))
+			}
+
+			if opts.regex && opts.pattern == "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.regexWithoutPattern"))
+			}
+
+			if opts.allMatching && opts.pattern == "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.allMatchingWithoutPattern"))
 			}
 
 			if opts.kafkaID != "" {
@@ -75,6 +104,9 @@ func NewDeleteTopicCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.LocalizeByID("kafka.topic.delete.flag.yes.description"))
+	cmd.Flags().StringVar(&opts.pattern, "pattern", "", opts.localizer.LocalizeByID("kafka.topic.delete.flag.pattern.description"))
+	cmd.Flags().BoolVar(&opts.regex, "regex", false, opts.localizer.LocalizeByID("kafka.topic.common.flag.regex.description"))
+	cmd.Flags().BoolVar(&opts.allMatching, "all-matching", false, opts.localizer.LocalizeByID("kafka.topic.delete.flag.allMatching.description"))
 
 	return cmd
 }
@@ -96,61 +128,190 @@ func runCmd(opts *Options) error {
 		return err
 	}
 
-	// perform delete topic API request
-	_, httpRes, err := api.TopicsApi.GetTopic(context.Background(), opts.topicName).
-		Execute()
-
-	topicNameTmplPair := localize.NewEntry("TopicName", opts.topicName)
 	kafkaNameTmplPair := localize.NewEntry("InstanceName", kafkaInstance.GetName())
+
+	topics, err := resolveTopics(opts, api, kafkaInstance.GetName())
 	if err != nil {
-		if httpRes == nil {
+		return err
+	}
+
+	if len(topics) == 0 {
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.delete.log.info.noMatches", localize.NewEntry("Pattern", opts.pattern), kafkaNameTmplPair))
+		return nil
+	}
+
+	if opts.pattern != "" && len(topics) > 1 && !opts.allMatching {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.multipleMatches", localize.NewEntry("Pattern", opts.pattern), localize.NewEntry("Count", len(topics))))
+	}
+
+	warnActiveConsumers(opts, logger, api, topics)
+
+	// topics matched by a pattern are always listed, so that it is clear what is deleted
+	if len(topics) > 1 || opts.pattern != "" {
+		printTopics(opts, topics, kafkaInstance.GetName())
+	}
+
+	if !opts.force {
+		if err = confirmDelete(opts, topics); err != nil {
 			return err
 		}
-		if httpRes.StatusCode == 404 {
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.topicNotFoundError", topicNameTmplPair, kafkaNameTmplPair))
+	}
+
+	// the error of a single topic is returned as it is
+	if len(topics) == 1 {
+		topicName := topics[0].GetName()
+		httpRes, err := api.TopicsApi.DeleteTopic(context.Background(), topicName).Execute()
+		if err != nil {
+			return topicutil.APIError(opts.localizer, httpRes, err, topicName, kafkaInstance.GetName(), "delete")
 		}
+
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.delete.log.info.topicDeleted", localize.NewEntry("TopicName", topicName), kafkaNameTmplPair))
+		return nil
 	}
 
-	if !opts.force {
-		promptConfirmName := &survey.Input{
-			Message: opts.localizer.LocalizeByID("kafka.topic.delete.input.name.message"),
+	errs := parallel.Run(len(topics), parallel.DefaultConcurrency, func(i int) error {
+		topicName := topics[i].GetName()
+		httpRes, err := api.TopicsApi.DeleteTopic(context.Background(), topicName).Execute()
+		if err != nil {
+			return topicutil.APIError(opts.localizer, httpRes, err, topicName, kafkaInstance.GetName(), "delete")
 		}
-		var userConfirmedName string
-		if err = survey.AskOne(promptConfirmName, &userConfirmedName); err != nil {
-			return err
+		return nil
+	})
+
+	for i, topic := range topics {
+		topicNameTmplPair := localize.NewEntry("TopicName", topic.GetName())
+		if errs[i] != nil {
+			logger.Error(opts.localizer.LocalizeByID("kafka.topic.delete.log.error.topicNotDeleted", topicNameTmplPair, localize.NewEntry("Error", errs[i])))
+			continue
 		}
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.delete.log.info.topicDeleted", topicNameTmplPair, kafkaNameTmplPair))
+	}
+
+	if failed := parallel.CountFailed(errs); failed > 0 {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.failed", localize.NewEntry("Count", failed), localize.NewEntry("Total", len(topics))))
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.topic.delete.log.info.topicsDeleted", localize.NewEntry("Count", len(topics)), kafkaNameTmplPair))
+
+	return nil
+}
 
-		if userConfirmedName != opts.topicName {
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.mismatchedNameConfirmation", localize.NewEntry("ConfirmedName", userConfirmedName), localize.NewEntry("ActualName", opts.topicName)))
+// resolveTopics fetches the topics given by name, or the non-internal topics matching --pattern
+func resolveTopics(opts *Options, api *kafkainstanceclient.APIClient, instanceName string) ([]kafkainstanceclient.Topic, error) {
+	ctx := context.Background()
+	topics := []kafkainstanceclient.Topic{}
+
+	if opts.pattern == "" {
+		seen := map[string]bool{}
+		for _, name := range opts.topicNames {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			topic, httpRes, err := api.TopicsApi.GetTopic(ctx, name).Execute()
+			if err != nil {
+				if httpRes != nil && httpRes.StatusCode == 404 {
+					return nil, errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.topicNotFoundError", localize.NewEntry("TopicName", name), localize.NewEntry("InstanceName", instanceName)))
+				}
+				return nil, topicutil.APIError(opts.localizer, httpRes, err, name, instanceName, "view")
+			}
+			topics = append(topics, topic)
 		}
+		return topics, nil
 	}
 
-	// perform delete topic API request
-	httpRes, err = api.TopicsApi.DeleteTopic(context.Background(), opts.topicName).
-		Execute()
+	pattern, err := topicutil.NewNamePattern(opts.pattern, opts.regex)
 	if err != nil {
-		if httpRes == nil {
-			return err
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.invalidPattern", localize.NewEntry("Pattern", opts.pattern), localize.NewEntry("Error", err)))
+	}
+
+	all, httpRes, err := topicutil.FetchAllTopics(ctx, api, "")
+	if err != nil {
+		return nil, topicutil.APIError(opts.localizer, httpRes, err, "", instanceName, "list")
+	}
+
+	for _, topic := range all {
+		if topicutil.IsInternalTopic(topic.GetName()) || !pattern.Match(topic.GetName()) {
+			continue
+		}
+		topics = append(topics, topic)
+	}
+
+	return topics, nil
+}
+
+// warnActiveConsumers warns about the consumer groups which have active members on the topics to delete
+func warnActiveConsumers(opts *Options, logger logging.Logger, api *kafkainstanceclient.APIClient, topics []kafkainstanceclient.Topic) {
+	for _, topic := range topics {
+		topicName := topic.GetName()
+		groups, _, err := consumergroup.FetchAllForTopic(context.Background(), api, topicName)
+		if err != nil {
+			// the warning is best effort, a failure must not prevent the deletion
+			if logger.DebugEnabled() {
+				logger.Infoln(opts.localizer.LocalizeByID("kafka.topic.delete.log.debug.consumersNotFetched", localize.NewEntry("TopicName", topicName), localize.NewEntry("Error", err)))
+			}
+			continue
+		}
+
+		activeGroups := []string{}
+		for _, group := range groups {
+			// partitions with committed offsets but no member are not actively consumed
+			consumers := consumergroup.FilterConsumersWithMember(consumergroup.FilterConsumersByTopic(group.GetConsumers(), topicName))
+			if consumergroup.GetActiveConsumersCount(consumers) > 0 {
+				activeGroups = append(activeGroups, group.GetGroupId())
+			}
 		}
 
-		operationTmplPair := localize.NewEntry("Operation", "delete")
-		switch httpRes.StatusCode {
-		case 404:
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.notFoundError", topicNameTmplPair, kafkaNameTmplPair))
-		case 401:
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.unauthorized", operationTmplPair))
-		case 403:
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.forbidden", operationTmplPair))
-		case 500:
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.internalServerError"))
-		case 503:
-			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.unableToConnectToKafka", localize.NewEntry("Name", kafkaInstance.GetName())))
-		default:
+		if len(activeGroups) > 0 {
+			logger.Info(opts.localizer.LocalizeByID("kafka.topic.delete.log.info.activeConsumers",
+				localize.NewEntry("TopicName", topicName), localize.NewEntry("Groups", strings.Join(activeGroups, ", "))))
+		}
+	}
+}
+
+// printTopics lists the topics to delete with their number of partitions on the error stream
+func printTopics(opts *Options, topics []kafkainstanceclient.Topic, instanceName string) {
+	rows := make([]topicRow, len(topics))
+	for i, topic := range topics {
+		rows[i] = topicRow{Name: topic.GetName(), Partitions: len(topic.GetPartitions())}
+	}
+
+	fmt.Fprintln(opts.IO.ErrOut, opts.localizer.LocalizeByID("kafka.topic.delete.log.info.topicsToDelete", localize.NewEntry("Count", len(topics)), localize.NewEntry("InstanceName", instanceName)))
+	dump.Table(opts.IO.ErrOut, rows)
+	fmt.Fprintln(opts.IO.ErrOut)
+}
+
+// confirmDelete asks to type the name of the topic, or a confirmation phrase when deleting several topics
+func confirmDelete(opts *Options, topics []kafkainstanceclient.Topic) error {
+	if len(topics) == 1 {
+		topicName := topics[0].GetName()
+		promptConfirmName := &survey.Input{
+			Message: opts.localizer.LocalizeByID("kafka.topic.delete.input.name.message"),
+		}
+		var userConfirmedName string
+		if err := survey.AskOne(promptConfirmName, &userConfirmedName); err != nil {
 			return err
 		}
+
+		if userConfirmedName != topicName {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.mismatchedNameConfirmation", localize.NewEntry("ConfirmedName", userConfirmedName), localize.NewEntry("ActualName", topicName)))
+		}
+		return nil
 	}
 
-	logger.Info(opts.localizer.LocalizeByID("kafka.topic.delete.log.info.topicDeleted", topicNameTmplPair, kafkaNameTmplPair))
+	phrase := opts.localizer.LocalizeByID("kafka.topic.delete.input.phrase.value", localize.NewEntry("Count", len(topics)))
+	promptConfirmPhrase := &survey.Input{
+		Message: opts.localizer.LocalizeByID("kafka.topic.delete.input.phrase.message", localize.NewEntry("Phrase", phrase)),
+	}
+	var userConfirmedPhrase string
+	if err := survey.AskOne(promptConfirmPhrase, &userConfirmedPhrase); err != nil {
+		return err
+	}
+
+	if userConfirmedPhrase != phrase {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.delete.error.mismatchedPhraseConfirmation", localize.NewEntry("Phrase", phrase)))
+	}
 
 	return nil
 }
@@ -4,31 +4,84 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil/core/utils/color"
 	"github.com/aerogear/charmil/core/utils/localize"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
 
 	"github.com/aerogear/charmil-host-example/pkg/dump"
 	"github.com/aerogear/charmil/core/utils/iostreams"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
 	"gopkg.in/yaml.v2"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 
 	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
 
 	"github.com/spf13/cobra"
 
 	"github.com/aerogear/charmil/core/utils/logging"
 )
 
+const (
+	configSourceDefault  = "default"
+	configSourceOverride = "override"
+)
+
+type configRow struct {
+	Key         string `json:"key" header:"Key"`
+	Value       string `json:"value" header:"Value"`
+	Source      string `json:"source,omitempty" header:"Source"`
+	Type        string `json:"type,omitempty" header:"Type"`
+	Description string `json:"description,omitempty" header:"Description"`
+}
+
+type partitionRow struct {
+	Partition       int32  `header:"Partition"`
+	Leader          string `header:"Leader"`
+	Replicas        string `header:"Replicas"`
+	ISR             string `header:"In-sync replicas"`
+	UnderReplicated string `header:"Under-replicated"`
+}
+
+type effectiveConfigRow struct {
+	Key    string `header:"Key"`
+	Value  string `header:"Value"`
+	Source string `header:"Source"`
+}
+
+type partitionDetails struct {
+	ID              int32    `json:"id"`
+	Leader          string   `json:"leader"`
+	Replicas        []string `json:"replicas"`
+	ISR             []string `json:"isr"`
+	UnderReplicated bool     `json:"underReplicated"`
+}
+
+type topicDetails struct {
+	Name                      string             `json:"name"`
+	Partitions                int                `json:"partitions"`
+	ReplicationFactor         int                `json:"replicationFactor"`
+	UnderReplicatedPartitions int                `json:"underReplicatedPartitions"`
+	PartitionDetails          []partitionDetails `json:"partitionDetails"`
+	Config                    []configRow        `json:"config"`
+}
+
 type Options struct {
 	topicName    string
 	kafkaID      string
 	outputFormat string
+	configs      bool
+	partitions   bool
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -68,6 +121,15 @@ func NewDescribeTopicCommand(f *factory.Factory) *cobra.Command {
 				}
 			}
 
+			if opts.configs && opts.partitions {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.describe.error.configsAndPartitions"))
+			}
+
+			// the config entries and partitions are printed as tables unless an output format is requested
+			if (opts.configs || opts.partitions) && !cmd.Flags().Changed("output") {
+				opts.outputFormat = ""
+			}
+
 			if opts.kafkaID != "" {
 				return runCmd(opts)
 			}
@@ -83,6 +145,8 @@ func NewDescribeTopicCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", "json", opts.localizer.LocalizeByID("kafka.topic.common.flag.output.description"))
+	cmd.Flags().BoolVar(&opts.configs, "configs", false, opts.localizer.LocalizeByID("kafka.topic.describe.flag.configs.description"))
+	cmd.Flags().BoolVar(&opts.partitions, "partitions", false, opts.localizer.LocalizeByID("kafka.topic.describe.flag.partitions.description"))
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -129,14 +193,136 @@ func runCmd(opts *Options) error {
 		}
 	}
 
+	var output interface{} = topicResponse
+	if opts.configs {
+		rows := mapConfigEntriesToRows(topicResponse.GetConfig())
+		if opts.outputFormat == "" {
+			// the table shows retention values with their human-friendly form, e.g. "604800000 (7d)"
+			for i, row := range rows {
+				if human := topicutil.FormatRetentionValue(row.Key, row.Value); human != "" {
+					rows[i].Value = fmt.Sprintf("%v (%v)", row.Value, human)
+				}
+			}
+			dump.Table(opts.IO.Out, rows)
+			return nil
+		}
+		output = rows
+	}
+
+	if opts.partitions {
+		details := mapTopicToDetails(topicResponse)
+		if opts.outputFormat == "" {
+			printTopicDetails(opts.IO.Out, details, opts.localizer)
+			return nil
+		}
+		output = details
+	}
+
 	switch opts.outputFormat {
 	case dump.JSONFormat:
-		data, _ := json.Marshal(topicResponse)
+		data, _ := json.Marshal(output)
 		_ = dump.JSON(opts.IO.Out, data)
 	case dump.YAMLFormat, dump.YMLFormat:
-		data, _ := yaml.Marshal(topicResponse)
+		data, _ := yaml.Marshal(output)
 		_ = dump.YAML(opts.IO.Out, data)
 	}
 
 	return nil
 }
+
+// mapConfigEntriesToRows returns all config entries of a topic sorted by key,
+// with the type and description of the entries which are in the config catalogue
+func mapConfigEntriesToRows(entries []kafkainstanceclient.ConfigEntry) []configRow {
+	rows := make([]configRow, 0, len(entries))
+	for _, entry := range entries {
+		row := configRow{
+			Key:   entry.GetKey(),
+			Value: entry.GetValue(),
+		}
+		if def, ok := topicutil.FindConfig(row.Key); ok {
+			row.Type = string(def.Type)
+			row.Description = def.Description
+			row.Source = configSourceOverride
+			if topicutil.IsDefaultConfigValue(row.Key, row.Value) {
+				row.Source = configSourceDefault
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Key < rows[j].Key
+	})
+
+	return rows
+}
+
+// mapTopicToDetails returns the partition details and effective config entries of a topic
+func mapTopicToDetails(topic kafkainstanceclient.Topic) topicDetails {
+	details := topicDetails{
+		Name:                      topic.GetName(),
+		Partitions:                len(topic.GetPartitions()),
+		ReplicationFactor:         topicutil.ReplicationFactor(topic),
+		UnderReplicatedPartitions: topicutil.CountUnderReplicated(topic),
+		PartitionDetails:          []partitionDetails{},
+		Config:                    mapConfigEntriesToRows(topic.GetConfig()),
+	}
+
+	for _, partition := range topic.GetPartitions() {
+		details.PartitionDetails = append(details.PartitionDetails, partitionDetails{
+			ID:              partition.GetId(),
+			Leader:          topicutil.NodeID(partition.GetLeader()),
+			Replicas:        topicutil.NodeIDs(partition.GetReplicas()),
+			ISR:             topicutil.NodeIDs(partition.GetIsr()),
+			UnderReplicated: topicutil.IsUnderReplicated(partition),
+		})
+	}
+
+	// sort partitions by ID
+	sort.Slice(details.PartitionDetails, func(i, j int) bool {
+		return details.PartitionDetails[i].ID < details.PartitionDetails[j].ID
+	})
+
+	return details
+}
+
+// print the summary, partitions and effective config entries of the topic
+func printTopicDetails(w io.Writer, details topicDetails, localizer localize.Localizer) {
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w,
+		color.Bold(localizer.LocalizeByID("kafka.topic.describe.output.partitions")), details.Partitions, "\t",
+		color.Bold(localizer.LocalizeByID("kafka.topic.describe.output.replicationFactor")), details.ReplicationFactor, "\t",
+		color.Bold(localizer.LocalizeByID("kafka.topic.describe.output.underReplicatedPartitions")), details.UnderReplicatedPartitions)
+	fmt.Fprintln(w, "")
+
+	partitionRows := make([]partitionRow, len(details.PartitionDetails))
+	for i, partition := range details.PartitionDetails {
+		row := partitionRow{
+			Partition:       partition.ID,
+			Leader:          partition.Leader,
+			Replicas:        strings.Join(partition.Replicas, ","),
+			ISR:             strings.Join(partition.ISR, ","),
+			UnderReplicated: "no",
+		}
+		// partitions with fewer in-sync replicas than replicas are highlighted
+		if partition.UnderReplicated {
+			row.UnderReplicated = color.Error("yes")
+		}
+		partitionRows[i] = row
+	}
+	dump.Table(w, partitionRows)
+	fmt.Fprintln(w, "")
+
+	configRows := make([]effectiveConfigRow, len(details.Config))
+	for i, row := range details.Config {
+		configRows[i] = effectiveConfigRow{Key: row.Key, Value: row.Value, Source: row.Source}
+		if human := topicutil.FormatRetentionValue(row.Key, row.Value); human != "" {
+			configRows[i].Value = fmt.Sprintf("%v (%v)", row.Value, human)
+		}
+		if row.Source == configSourceOverride {
+			configRows[i].Source = color.Bold(row.Source)
+		}
+	}
+	fmt.Fprintln(w, color.Bold(localizer.LocalizeByID("kafka.topic.describe.output.config")))
+	dump.Table(w, configRows)
+}
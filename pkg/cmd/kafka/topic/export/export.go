@@ -0,0 +1,158 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sort"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	kafkaID         string
+	outputFormat    string
+	file            string
+	pattern         string
+	regex           bool
+	includeInternal bool
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewExportTopicCommand gets a new command for exporting kafka topics to a manifest.
+func NewExportTopicCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.topic.export.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.topic.export.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.topic.export.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.topic.export.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if err = flag.ValidateOutput(opts.outputFormat); err != nil {
+				return err
+			}
+
+			if opts.regex && opts.pattern == "" {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.regexWithoutPattern"))
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.outputFormat, "output", "o", dump.YAMLFormat, opts.localizer.LocalizeByID("kafka.topic.export.flag.output.description"))
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", opts.localizer.LocalizeByID("kafka.topic.export.flag.file.description"))
+	cmd.Flags().StringVar(&opts.pattern, "pattern", "", opts.localizer.LocalizeByID("kafka.topic.common.flag.pattern.description"))
+	cmd.Flags().BoolVar(&opts.regex, "regex", false, opts.localizer.LocalizeByID("kafka.topic.common.flag.regex.description"))
+	cmd.Flags().BoolVar(&opts.includeInternal, "include-internal", false, opts.localizer.LocalizeByID("kafka.topic.export.flag.includeInternal.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+// nolint:funlen
+func runCmd(opts *Options) error {
+	var pattern *topicutil.NamePattern
+	if opts.pattern != "" {
+		var err error
+		if pattern, err = topicutil.NewNamePattern(opts.pattern, opts.regex); err != nil {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.invalidPattern", localize.NewEntry("Pattern", opts.pattern), localize.NewEntry("Error", err)))
+		}
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	api, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	topics, httpRes, err := topicutil.FetchAllTopics(context.Background(), api, "")
+	if err != nil {
+		return topicutil.APIError(opts.localizer, httpRes, err, "", kafkaInstance.GetName(), "list")
+	}
+
+	manifest := topicutil.Manifest{Topics: []topicutil.TopicSpec{}}
+	for _, topic := range topics {
+		name := topic.GetName()
+		if !opts.includeInternal && topicutil.IsInternalTopic(name) {
+			continue
+		}
+		if pattern != nil && !pattern.Match(name) {
+			continue
+		}
+		manifest.Topics = append(manifest.Topics, topicutil.NewTopicSpec(topic))
+	}
+
+	sort.Slice(manifest.Topics, func(i, j int) bool {
+		return manifest.Topics[i].Name < manifest.Topics[j].Name
+	})
+
+	var data []byte
+	switch opts.outputFormat {
+	case dump.JSONFormat:
+		data, err = json.MarshalIndent(manifest, "", "  ")
+	default:
+		data, err = yaml.Marshal(manifest)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.file == "" {
+		if opts.outputFormat == dump.JSONFormat {
+			_ = dump.JSON(opts.IO.Out, data)
+		} else {
+			_ = dump.YAML(opts.IO.Out, data)
+		}
+	} else if err = ioutil.WriteFile(opts.file, data, 0600); err != nil {
+		return err
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.topic.export.log.info.exported",
+		localize.NewEntry("Count", len(manifest.Topics)),
+		localize.NewEntry("InstanceName", kafkaInstance.GetName()),
+	))
+
+	return nil
+}
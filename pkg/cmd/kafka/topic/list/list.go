@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
 	topicutil "github.com/aerogear/charmil-host-example/pkg/kafka/topic"
 	"github.com/aerogear/charmil/core/utils/localize"
 
@@ -40,6 +42,8 @@ type Options struct {
 	search  string
 	page    int32
 	size    int32
+
+	listFlags listutil.Flags
 }
 
 type topicRow struct {
@@ -73,11 +77,19 @@ func NewListTopicCommand(f *factory.Factory) *cobra.Command {
 			}
 
 			if opts.page < 1 {
-				return errors.New(opts.localizer.LocalizeByID("kafka.common.page.error.invalid.minValue", localize.NewEntry("Page", opts.page)))
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.page.error.invalid.minValue", localize.NewEntry("Page", opts.page)))
 			}
 
 			if opts.size < 1 {
-				return errors.New(opts.localizer.LocalizeByID("kafka.common.size.error.invalid.minValue", localize.NewEntry("Size", opts.size)))
+				return errors.New(opts.localizer.LocalizeByID("kafka.common.validation.size.error.invalid.minValue", localize.NewEntry("Size", opts.size)))
+			}
+
+			if opts.listFlags.All && cmd.Flags().Changed("page") {
+				return errors.New(opts.localizer.LocalizeByID("list.error.allWithPage"))
+			}
+
+			if err := opts.listFlags.Validate(topicRow{}); err != nil {
+				return err
 			}
 
 			if opts.search != "" {
@@ -103,6 +115,8 @@ func NewListTopicCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().StringVarP(&opts.search, "search", "", "", opts.localizer.LocalizeByID("kafka.topic.list.flag.search.description"))
 	cmd.Flags().Int32VarP(&opts.page, "page", "", int32(cmdutil.DefaultPageNumber), opts.localizer.LocalizeByID("kafka.topic.list.flag.page.description"))
 	cmd.Flags().Int32VarP(&opts.size, "size", "", int32(cmdutil.DefaultPageSize), opts.localizer.LocalizeByID("kafka.topic.list.flag.size.description"))
+	listutil.AddPaginationFlags(cmd, &opts.listFlags, opts.localizer)
+	listutil.AddTableFlags(cmd, &opts.listFlags, topicRow{}, nil, opts.localizer)
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -125,18 +139,50 @@ func runCmd(opts *Options) error {
 		return err
 	}
 
-	a := api.TopicsApi.GetTopics(context.Background())
-
 	if opts.search != "" {
 		logger.Infoln(opts.localizer.LocalizeByID("kafka.topic.list.log.debug.filteringTopicList", localize.NewEntry("Search", opts.search)))
-		a = a.Filter(opts.search)
 	}
 
-	a = a.Size(opts.size)
+	fetchPage := func(page int32) (kafkainstanceclient.TopicsList, *http.Response, error) {
+		a := api.TopicsApi.GetTopics(context.Background())
+
+		if opts.search != "" {
+			a = a.Filter(opts.search)
+		}
+
+		a = a.Size(opts.size)
 
-	a = a.Page(opts.page)
+		a = a.Page(page)
+
+		topicData, httpRes, err := a.Execute()
+		if httpRes != nil {
+			defer httpRes.Body.Close()
+		}
 
-	topicData, httpRes, err := a.Execute()
+		return topicData, httpRes, err
+	}
+
+	var topicData kafkainstanceclient.TopicsList
+	var httpRes *http.Response
+	if opts.listFlags.All {
+		items := []kafkainstanceclient.Topic{}
+		err = listutil.FetchAll(func(page int32) (int, int, error) {
+			var pageData kafkainstanceclient.TopicsList
+			pageData, httpRes, err = fetchPage(page)
+			if err != nil {
+				return 0, 0, err
+			}
+			items = append(items, pageData.GetItems()...)
+
+			return len(pageData.GetItems()), int(pageData.GetTotal()), nil
+		})
+		topicData.SetItems(items)
+		topicData.SetTotal(int32(len(items)))
+		topicData.SetPage(1)
+		topicData.SetSize(int32(len(items)))
+	} else {
+		topicData, httpRes, err = fetchPage(opts.page)
+	}
 	if err != nil {
 		if httpRes == nil {
 			return err
@@ -158,14 +204,21 @@ func runCmd(opts *Options) error {
 		}
 	}
 
-	defer httpRes.Body.Close()
-
 	if topicData.GetTotal() == 0 && opts.output == "" {
 		logger.Info(opts.localizer.LocalizeByID("kafka.topic.list.log.info.noTopics", localize.NewEntry("InstanceName", kafkaInstance.GetName())))
 
 		return nil
 	}
 
+	topics := topicData.GetItems()
+	rows := mapTopicResultsToTableFormat(topics)
+	if opts.listFlags.SortBy != "" {
+		if err = listutil.Sort(rows, opts.listFlags.SortBy, topics); err != nil {
+			return err
+		}
+		topicData.SetItems(topics)
+	}
+
 	stdout := opts.IO.Out
 	switch opts.output {
 	case dump.JSONFormat:
@@ -175,9 +228,9 @@ func runCmd(opts *Options) error {
 		data, _ := yaml.Marshal(topicData)
 		_ = dump.YAML(stdout, data)
 	default:
-		topics := topicData.GetItems()
-		rows := mapTopicResultsToTableFormat(topics)
-		dump.Table(stdout, rows)
+		if err = opts.listFlags.Table(stdout, rows); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -192,24 +245,13 @@ func mapTopicResultsToTableFormat(topics []kafkainstanceclient.Topic) []topicRow
 			Name:            t.GetName(),
 			PartitionsCount: len(t.GetPartitions()),
 		}
+		// retention values are shown with their human-friendly form, e.g. "604800000 (7d)"
 		for _, config := range t.GetConfig() {
-			unlimitedVal := "-1 (Unlimited)"
-
 			if *config.Key == topicutil.RetentionMsKey {
-				val := config.GetValue()
-				if val == "-1" {
-					row.RetentionTime = unlimitedVal
-				} else {
-					row.RetentionTime = val
-				}
+				row.RetentionTime = withHumanValue(config.GetKey(), config.GetValue())
 			}
 			if *config.Key == topicutil.RetentionSizeKey {
-				val := config.GetValue()
-				if val == "-1" {
-					row.RetentionSize = unlimitedVal
-				} else {
-					row.RetentionSize = val
-				}
+				row.RetentionSize = withHumanValue(config.GetKey(), config.GetValue())
 			}
 		}
 
@@ -218,3 +260,11 @@ func mapTopicResultsToTableFormat(topics []kafkainstanceclient.Topic) []topicRow
 
 	return rows
 }
+
+// withHumanValue appends the human-friendly form of a retention value to the raw value
+func withHumanValue(key string, value string) string {
+	if human := topicutil.FormatRetentionValue(key, value); human != "" {
+		return fmt.Sprintf("%v (%v)", value, human)
+	}
+	return value
+}
@@ -0,0 +1,186 @@
+package produce
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+)
+
+type Options struct {
+	topicName       string
+	kafkaID         string
+	file            string
+	format          string
+	key             string
+	keySeparator    string
+	headers         []string
+	partition       int
+	credentialsFile string
+
+	IO         *iostreams.IOStreams
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	localizer  localize.Localizer
+}
+
+// NewProduceTopicCommand gets a new command for producing messages to a kafka topic.
+func NewProduceTopicCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		Connection: f.Connection,
+		CfgHandler: f.CfgHandler,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("kafka.topic.produce.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("kafka.topic.produce.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("kafka.topic.produce.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("kafka.topic.produce.cmd.example"),
+		Args:    cobra.ExactValidArgs(1),
+		// dynamic completion of topic names
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cmdutil.FilterValidTopicNameArgs(f, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.topicName = args[0]
+
+			if !flagutil.IsValidInput(opts.format, messaging.ValidFormats...) {
+				return flag.InvalidValueError("format", opts.format, messaging.ValidFormats...)
+			}
+
+			if opts.partition < messaging.AnyPartition {
+				return flag.InvalidValueError("partition", opts.partition)
+			}
+
+			if !f.CfgHandler.Cfg.HasKafka() {
+				return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.noKafkaSelected"))
+			}
+
+			opts.kafkaID = opts.CfgHandler.Cfg.Services.Kafka.ClusterID
+
+			return runCmd(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "-", opts.localizer.LocalizeByID("kafka.topic.produce.flag.file.description"))
+	cmd.Flags().StringVar(&opts.format, "format", messaging.FormatRaw, opts.localizer.LocalizeByID("kafka.topic.produce.flag.format.description"))
+	cmd.Flags().StringVar(&opts.key, "key", "", opts.localizer.LocalizeByID("kafka.topic.produce.flag.key.description"))
+	cmd.Flags().StringVar(&opts.keySeparator, "key-separator", "", opts.localizer.LocalizeByID("kafka.topic.produce.flag.keySeparator.description"))
+	cmd.Flags().StringArrayVar(&opts.headers, "header", []string{}, opts.localizer.LocalizeByID("kafka.topic.produce.flag.header.description"))
+	cmd.Flags().IntVar(&opts.partition, "partition", messaging.AnyPartition, opts.localizer.LocalizeByID("kafka.topic.produce.flag.partition.description"))
+	cmd.Flags().StringVar(&opts.credentialsFile, "credentials-file", "", opts.localizer.LocalizeByID("kafka.topic.common.flag.credentialsFile.description"))
+
+	flagutil.EnableStaticFlagCompletion(cmd, "format", messaging.ValidFormats)
+
+	return cmd
+}
+
+func runCmd(opts *Options) error {
+	headers := make([]messaging.Header, len(opts.headers))
+	for i, h := range opts.headers {
+		header, err := messaging.ParseHeader(h)
+		if err != nil {
+			return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.invalidHeader", localize.NewEntry("Header", h)))
+		}
+		headers[i] = header
+	}
+
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	messages, err := readMessages(opts, logger, messaging.InputOptions{
+		Format:       opts.format,
+		Key:          opts.key,
+		KeySeparator: opts.keySeparator,
+		Headers:      headers,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(messages) == 0 {
+		logger.Info(opts.localizer.LocalizeByID("kafka.topic.produce.log.info.noMessages"))
+		return nil
+	}
+
+	if opts.partition != messaging.AnyPartition {
+		for i := range messages {
+			messages[i].Partition = opts.partition
+		}
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigRequireMasAuth)
+	if err != nil {
+		return err
+	}
+
+	_, kafkaInstance, err := conn.API().KafkaAdmin(opts.kafkaID)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := messaging.NewTokenSource(opts.CfgHandler.Cfg, opts.credentialsFile)
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.common.error.invalidCredentialsFile", localize.NewEntry("File", opts.credentialsFile), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	cfg, err := messaging.ConfigForKafka(kafkaInstance, tokens, opts.CfgHandler.Cfg.Insecure)
+	if err != nil {
+		return err
+	}
+
+	client := messaging.NewClient(cfg)
+	defer client.Close()
+
+	produced, err := client.Produce(context.Background(), opts.topicName, messages)
+	if err != nil {
+		return errors.New(opts.localizer.LocalizeByID("kafka.topic.produce.error.produceFailed", localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName()), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	logger.Info(opts.localizer.LocalizeByID("kafka.topic.produce.log.info.produced", localize.NewEntry("Count", len(produced)), localize.NewEntry("TopicName", opts.topicName), localize.NewEntry("InstanceName", kafkaInstance.GetName())))
+
+	return nil
+}
+
+// readMessages reads the messages from --file, or from standard input when it is "-"
+func readMessages(opts *Options, logger logging.Logger, inputOpts messaging.InputOptions) ([]messaging.Message, error) {
+	var r io.Reader
+	if opts.file == "-" {
+		if opts.IO.IsStdinTTY() {
+			logger.Info(opts.localizer.LocalizeByID("kafka.topic.produce.log.info.readingStdin"))
+		}
+		r = opts.IO.In
+	} else {
+		f, err := os.Open(opts.file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	messages, err := messaging.ReadMessages(r, inputOpts)
+	if err != nil {
+		return nil, errors.New(opts.localizer.LocalizeByID("kafka.topic.produce.error.invalidInput", localize.NewEntry("File", opts.file), localize.NewEntry("ErrorMessage", err)))
+	}
+
+	return messages, nil
+}
@@ -4,10 +4,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/apply"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/consume"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/consumers"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/copy"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/create"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/delete"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/describe"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/export"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/list"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/produce"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/kafka/topic/update"
 )
 
@@ -30,6 +36,12 @@ func NewTopicCommand(f *factory.Factory) *cobra.Command {
 		delete.NewDeleteTopicCommand(f),
 		describe.NewDescribeTopicCommand(f),
 		update.NewUpdateTopicCommand(f),
+		apply.NewApplyTopicCommand(f),
+		export.NewExportTopicCommand(f),
+		copy.NewCopyTopicCommand(f),
+		consumers.NewConsumersTopicCommand(f),
+		produce.NewProduceTopicCommand(f),
+		consume.NewConsumeTopicCommand(f),
 	)
 
 	return cmd
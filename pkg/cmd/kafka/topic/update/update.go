@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strconv"
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -45,6 +46,9 @@ type Options struct {
 	outputFormat      string
 	interactive       bool
 	cleanupPolicy     string
+	configPairs       []string
+	configFile        string
+	configEntries     map[string]string
 
 	IO         *iostreams.IOStreams
 	CfgHandler *config.CfgHandler
@@ -79,9 +83,18 @@ func NewUpdateTopicCommand(f *factory.Factory) *cobra.Command {
 				Localizer: opts.localizer,
 			}
 
-			if !opts.IO.CanPrompt() && opts.retentionMsStr == "" && opts.partitionsStr == "" && opts.retentionBytesStr == "" {
+			opts.configEntries, err = topicutil.LoadConfigEntries(opts.localizer, opts.configPairs, opts.configFile)
+			if err != nil {
+				return err
+			}
+
+			if err = topicutil.ValidateConfigFlagConflicts(opts.localizer, opts.configEntries, cmd.Flags().Changed); err != nil {
+				return err
+			}
+
+			if !opts.IO.CanPrompt() && opts.retentionMsStr == "" && opts.partitionsStr == "" && opts.retentionBytesStr == "" && len(opts.configEntries) == 0 {
 				return errors.New(opts.localizer.LocalizeByID("argument.error.requiredWhenNonInteractive", localize.NewEntry("Argument", "name")))
-			} else if opts.retentionMsStr == "" && opts.partitionsStr == "" && opts.retentionBytesStr == "" && opts.cleanupPolicy == "" {
+			} else if opts.retentionMsStr == "" && opts.partitionsStr == "" && opts.retentionBytesStr == "" && opts.cleanupPolicy == "" && len(opts.configEntries) == 0 {
 				opts.interactive = true
 			}
 
@@ -95,7 +108,7 @@ func NewUpdateTopicCommand(f *factory.Factory) *cobra.Command {
 					return err
 				}
 
-				if opts.retentionMsStr == "" && opts.partitionsStr == "" && opts.retentionBytesStr == "" && opts.cleanupPolicy == "" {
+				if opts.retentionMsStr == "" && opts.partitionsStr == "" && opts.retentionBytesStr == "" && opts.cleanupPolicy == "" && len(opts.configEntries) == 0 {
 					logger.Info(opts.localizer.LocalizeByID("kafka.topic.update.log.info.nothingToUpdate"))
 					return nil
 				}
@@ -168,6 +181,8 @@ func NewUpdateTopicCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.retentionBytesStr, "retention-bytes", "", opts.localizer.LocalizeByID("kafka.topic.common.input.retentionBytes.description"))
 	cmd.Flags().StringVar(&opts.cleanupPolicy, "cleanup-policy", "", opts.localizer.LocalizeByID("kafka.topic.common.input.cleanupPolicy.description"))
 	cmd.Flags().StringVar(&opts.partitionsStr, "partitions", "", opts.localizer.LocalizeByID("kafka.topic.common.input.partitions.description"))
+	cmd.Flags().StringArrayVar(&opts.configPairs, "config", []string{}, opts.localizer.LocalizeByID("kafka.topic.common.flag.config.description"))
+	cmd.Flags().StringVar(&opts.configFile, "config-file", "", opts.localizer.LocalizeByID("kafka.topic.common.flag.configFile.description"))
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -245,14 +260,17 @@ func runCmd(opts *Options) error {
 
 	topicSettings := &kafkainstanceclient.UpdateTopicInput{}
 
+	// retention values can be given in human units, so the converted numbers are sent
 	if opts.retentionMsStr != "" {
 		needsUpdate = true
-		configEntryMap[topicutil.RetentionMsKey] = &opts.retentionMsStr
+		retentionMsValue := strconv.Itoa(retentionPeriodMs)
+		configEntryMap[topicutil.RetentionMsKey] = &retentionMsValue
 	}
 
 	if opts.retentionBytesStr != "" {
 		needsUpdate = true
-		configEntryMap[topicutil.RetentionSizeKey] = &opts.retentionBytesStr
+		retentionBytesValue := strconv.Itoa(retentionSizeBytes)
+		configEntryMap[topicutil.RetentionSizeKey] = &retentionBytesValue
 	}
 
 	if opts.cleanupPolicy != "" && strings.Compare(opts.cleanupPolicy, topicutil.GetConfigValue(topic.GetConfig(), topicutil.CleanupPolicy)) != 0 {
@@ -260,6 +278,14 @@ func runCmd(opts *Options) error {
 		configEntryMap[topicutil.CleanupPolicy] = &opts.cleanupPolicy
 	}
 
+	for key, value := range opts.configEntries {
+		if value != topicutil.GetConfigValue(topic.GetConfig(), key) {
+			needsUpdate = true
+			value := value
+			configEntryMap[key] = &value
+		}
+	}
+
 	if opts.partitionsStr != "" {
 		needsUpdate = true
 		topicSettings.SetNumPartitions(partitionCount)
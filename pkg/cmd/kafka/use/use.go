@@ -53,8 +53,11 @@ func NewUseCommand(f *factory.Factory) *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
+				if opts.name != "" {
+					return errors.New(opts.localizer.LocalizeByID("kafka.common.error.nameArgAndFlag"))
+				}
 				opts.name = args[0]
-			} else if opts.id == "" {
+			} else if opts.id == "" && opts.name == "" {
 				if !opts.IO.CanPrompt() {
 					return errors.New(opts.localizer.LocalizeByID("kafka.use.error.idOrNameRequired"))
 				}
@@ -70,6 +73,11 @@ func NewUseCommand(f *factory.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("kafka.use.flag.id"))
+	cmd.Flags().StringVar(&opts.name, "name", "", opts.localizer.LocalizeByID("kafka.use.flag.name"))
+
+	_ = cmd.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return cmdutil.FilterValidKafkas(f, toComplete)
+	})
 
 	return cmd
 }
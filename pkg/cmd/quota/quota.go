@@ -0,0 +1,210 @@
+package quota
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/ams"
+	"github.com/aerogear/charmil-host-example/pkg/api/ams/amsclient"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil/core/utils/iostreams"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/aerogear/charmil/core/utils/logging"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+type Options struct {
+	CfgHandler *config.CfgHandler
+	Connection factory.ConnectionFunc
+	Logger     func() (logging.Logger, error)
+	IO         *iostreams.IOStreams
+	localizer  localize.Localizer
+
+	output string
+	all    bool
+}
+
+// quotaResponse is the document printed in JSON and YAML formats
+type quotaResponse struct {
+	OrganizationID string                   `json:"organization_id" yaml:"organization_id"`
+	QuotaSummary   []amsclient.QuotaSummary `json:"quota_summary" yaml:"quota_summary"`
+	QuotaCost      []amsclient.QuotaCost    `json:"quota_cost" yaml:"quota_cost"`
+}
+
+type quotaCostRow struct {
+	QuotaID   string `json:"quota_id" header:"Quota ID"`
+	Products  string `json:"products" header:"Products"`
+	Allowed   int32  `json:"allowed" header:"Allowed"`
+	Consumed  int32  `json:"consumed" header:"Consumed"`
+	Remaining int32  `json:"remaining" header:"Remaining"`
+}
+
+type quotaSummaryRow struct {
+	ResourceName     string `json:"resource_name" header:"Resource"`
+	ResourceType     string `json:"resource_type" header:"Type"`
+	AvailabilityZone string `json:"availability_zone_type" header:"Availability zone"`
+	Byoc             bool   `json:"byoc" header:"BYOC"`
+	Allowed          int32  `json:"allowed" header:"Allowed"`
+	Reserved         int32  `json:"reserved" header:"Reserved"`
+}
+
+// NewQuotaCommand creates a new command to view the quota of the user's organization
+func NewQuotaCommand(f *factory.Factory) *cobra.Command {
+	opts := &Options{
+		CfgHandler: f.CfgHandler,
+		Connection: f.Connection,
+		Logger:     f.Logger,
+		IO:         f.IOStreams,
+		localizer:  f.Localizer,
+	}
+
+	cmd := &cobra.Command{
+		Use:     opts.localizer.LocalizeByID("quota.cmd.use"),
+		Short:   opts.localizer.LocalizeByID("quota.cmd.shortDescription"),
+		Long:    opts.localizer.LocalizeByID("quota.cmd.longDescription"),
+		Example: opts.localizer.LocalizeByID("quota.cmd.example"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if opts.output != "" && !flagutil.IsValidInput(opts.output, flagutil.ValidOutputFormats...) {
+				return flag.InvalidValueError("output", opts.output, flagutil.ValidOutputFormats...)
+			}
+
+			return runQuota(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", opts.localizer.LocalizeByID("quota.flag.output.description"))
+	cmd.Flags().BoolVar(&opts.all, "all", false, opts.localizer.LocalizeByID("quota.flag.all.description"))
+
+	flagutil.EnableOutputFlagCompletion(cmd)
+
+	return cmd
+}
+
+func runQuota(opts *Options) error {
+	logger, err := opts.Logger()
+	if err != nil {
+		return err
+	}
+
+	conn, err := opts.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	orgID, err := ams.GetOrganizationID(conn)
+	if err != nil {
+		return err
+	}
+
+	summary, err := ams.GetQuotaSummary(conn, orgID)
+	if err != nil {
+		return err
+	}
+
+	costs, err := ams.GetQuotaCosts(conn, orgID)
+	if err != nil {
+		return err
+	}
+
+	if !opts.all {
+		summary, costs = filterApplicationServices(summary, costs)
+	}
+
+	if len(summary) == 0 && len(costs) == 0 && opts.output == "" {
+		logger.Info(opts.localizer.LocalizeByID("quota.log.info.noQuota"))
+		return nil
+	}
+
+	response := quotaResponse{
+		OrganizationID: orgID,
+		QuotaSummary:   summary,
+		QuotaCost:      costs,
+	}
+
+	switch opts.output {
+	case dump.JSONFormat:
+		data, _ := json.MarshalIndent(response, "", cmdutil.DefaultJSONIndent)
+		return dump.JSON(opts.IO.Out, data)
+	case dump.YAMLFormat, dump.YMLFormat:
+		data, _ := yaml.Marshal(response)
+		return dump.YAML(opts.IO.Out, data)
+	}
+
+	if len(costs) > 0 {
+		logger.Info(opts.localizer.LocalizeByID("quota.log.info.quotaCost"))
+		dump.Table(opts.IO.Out, mapQuotaCostToRows(costs))
+		logger.Info("")
+	}
+
+	if len(summary) > 0 {
+		logger.Info(opts.localizer.LocalizeByID("quota.log.info.quotaSummary"))
+		dump.Table(opts.IO.Out, mapQuotaSummaryToRows(summary))
+	}
+
+	return nil
+}
+
+// filterApplicationServices removes the quota which is not used by application services
+func filterApplicationServices(summary []amsclient.QuotaSummary, costs []amsclient.QuotaCost) ([]amsclient.QuotaSummary, []amsclient.QuotaCost) {
+	filteredSummary := []amsclient.QuotaSummary{}
+	for i := range summary {
+		if ams.IsApplicationServicesSummary(&summary[i]) {
+			filteredSummary = append(filteredSummary, summary[i])
+		}
+	}
+
+	filteredCosts := []amsclient.QuotaCost{}
+	for i := range costs {
+		if ams.IsApplicationServicesQuota(&costs[i]) {
+			filteredCosts = append(filteredCosts, costs[i])
+		}
+	}
+
+	return filteredSummary, filteredCosts
+}
+
+func mapQuotaCostToRows(costs []amsclient.QuotaCost) []quotaCostRow {
+	rows := make([]quotaCostRow, 0, len(costs))
+	for i := range costs {
+		c := &costs[i]
+		remaining := c.GetAllowed() - c.GetConsumed()
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		rows = append(rows, quotaCostRow{
+			QuotaID:   c.GetQuotaId(),
+			Products:  strings.Join(ams.QuotaProducts(c), ", "),
+			Allowed:   c.GetAllowed(),
+			Consumed:  c.GetConsumed(),
+			Remaining: remaining,
+		})
+	}
+
+	return rows
+}
+
+func mapQuotaSummaryToRows(summary []amsclient.QuotaSummary) []quotaSummaryRow {
+	rows := make([]quotaSummaryRow, 0, len(summary))
+	for i := range summary {
+		s := &summary[i]
+		rows = append(rows, quotaSummaryRow{
+			ResourceName:     s.GetResourceName(),
+			ResourceType:     s.GetResourceType(),
+			AvailabilityZone: s.GetAvailabilityZoneType(),
+			Byoc:             s.GetByoc(),
+			Allowed:          s.GetAllowed(),
+			Reserved:         s.GetReserved(),
+		})
+	}
+
+	return rows
+}
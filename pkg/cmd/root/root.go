@@ -8,6 +8,7 @@ import (
 	"github.com/aerogear/charmil-host-example/internal/build"
 
 	"github.com/aerogear/charmil-host-example/pkg/cmd/login"
+	"github.com/aerogear/charmil-host-example/pkg/cmd/quota"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/status"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/whoami"
 	"github.com/aerogear/charmil-host-example/pkg/config"
@@ -62,6 +63,7 @@ func NewRootCommand(f *factory.Factory, version string) *cobra.Command {
 	cmd.AddCommand(serviceaccount.NewServiceAccountCommand(f))
 	cmd.AddCommand(cluster.NewClusterCommand(f))
 	cmd.AddCommand(status.NewStatusCommand(f))
+	cmd.AddCommand(quota.NewQuotaCommand(f))
 	cmd.AddCommand(completion.NewCompletionCommand(f))
 	cmd.AddCommand(whoami.NewWhoAmICmd(f))
 	cmd.AddCommand(cliversion.NewVersionCmd(f))
@@ -11,6 +11,7 @@ import (
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
 
 	"github.com/AlecAivazis/survey/v2"
 	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
@@ -38,7 +39,8 @@ type Options struct {
 	description string
 	filename    string
 
-	interactive bool
+	interactive   bool
+	skipPreflight bool
 }
 
 // NewCreateCommand creates a new command to create service accounts
@@ -97,6 +99,7 @@ func NewCreateCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().BoolVar(&opts.overwrite, "overwrite", false, opts.localizer.LocalizeByID("serviceAccount.common.flag.overwrite.description"))
 	cmd.Flags().StringVar(&opts.filename, "file-location", "", opts.localizer.LocalizeByID("serviceAccount.common.flag.fileLocation.description"))
 	cmd.Flags().StringVar(&opts.fileFormat, "file-format", "", opts.localizer.LocalizeByID("serviceAccount.common.flag.fileFormat.description"))
+	preflight.AddFlag(cmd, &opts.skipPreflight, opts.localizer)
 
 	flagutil.EnableStaticFlagCompletion(cmd, "file-format", flagutil.CredentialsOutputFormats)
 
@@ -115,6 +118,12 @@ func runCreate(opts *Options) error {
 		return err
 	}
 
+	if !opts.skipPreflight {
+		if err = preflight.Check(connection, opts.localizer, preflight.CreateServiceAccount); err != nil {
+			return err
+		}
+	}
+
 	if opts.interactive {
 		// run the create command interactively
 		err = runInteractivePrompt(opts)
@@ -9,6 +9,7 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
 	"github.com/aerogear/charmil-host-example/pkg/serviceaccount/validation"
 	"github.com/aerogear/charmil/core/utils/iostreams"
 	"github.com/aerogear/charmil/core/utils/localize"
@@ -24,8 +25,9 @@ type Options struct {
 	Logger     func() (logging.Logger, error)
 	localizer  localize.Localizer
 
-	id    string
-	force bool
+	id            string
+	force         bool
+	skipPreflight bool
 }
 
 // NewDeleteCommand creates a new command to delete a service account
@@ -64,6 +66,7 @@ func NewDeleteCommand(f *factory.Factory) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.id, "id", "", opts.localizer.LocalizeByID("serviceAccount.delete.flag.id.description"))
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.LocalizeByID("serviceAccount.delete.flag.yes.description"))
+	preflight.AddFlag(cmd, &opts.skipPreflight, opts.localizer)
 
 	_ = cmd.MarkFlagRequired("id")
 
@@ -81,6 +84,12 @@ func runDelete(opts *Options) (err error) {
 		return err
 	}
 
+	if !opts.skipPreflight {
+		if err = preflight.Check(connection, opts.localizer, preflight.DeleteServiceAccount); err != nil {
+			return err
+		}
+	}
+
 	_, httpRes, err := connection.API().ServiceAccount().GetServiceAccountById(context.Background(), opts.id).Execute()
 	if err != nil {
 		if httpRes == nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
 	"github.com/aerogear/charmil-host-example/pkg/cmdutil"
 	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
 	"github.com/aerogear/charmil-host-example/pkg/config"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
 	"github.com/aerogear/charmil-host-example/pkg/dump"
@@ -27,7 +28,8 @@ type Options struct {
 	IO         *iostreams.IOStreams
 	localizer  localize.Localizer
 
-	output string
+	output    string
+	listFlags listutil.Flags
 }
 
 // svcAcctRow contains the properties used to
@@ -61,11 +63,16 @@ func NewListCommand(f *factory.Factory) *cobra.Command {
 				return flag.InvalidValueError("output", opts.output, flagutil.ValidOutputFormats...)
 			}
 
+			if err := opts.listFlags.Validate(svcAcctRow{}); err != nil {
+				return err
+			}
+
 			return runList(opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&opts.output, "output", "o", "", opts.localizer.LocalizeByID("serviceAccount.list.flag.output.description"))
+	listutil.AddTableFlags(cmd, &opts.listFlags, svcAcctRow{}, nil, opts.localizer)
 
 	flagutil.EnableOutputFlagCompletion(cmd)
 
@@ -94,6 +101,14 @@ func runList(opts *Options) (err error) {
 		return nil
 	}
 
+	rows := mapResponseItemsToRows(serviceaccounts)
+	if opts.listFlags.SortBy != "" {
+		if err = listutil.Sort(rows, opts.listFlags.SortBy, serviceaccounts); err != nil {
+			return err
+		}
+		res.SetItems(serviceaccounts)
+	}
+
 	outStream := opts.IO.Out
 	switch opts.output {
 	case dump.JSONFormat:
@@ -103,8 +118,9 @@ func runList(opts *Options) (err error) {
 		data, _ := yaml.Marshal(res)
 		_ = dump.YAML(outStream, data)
 	default:
-		rows := mapResponseItemsToRows(serviceaccounts)
-		dump.Table(outStream, rows)
+		if err = opts.listFlags.Table(outStream, rows); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -9,6 +9,7 @@ import (
 	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
 
 	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/preflight"
 	"github.com/aerogear/charmil/core/utils/localize"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -37,8 +38,9 @@ type Options struct {
 	overwrite  bool
 	filename   string
 
-	interactive bool
-	force       bool
+	interactive   bool
+	force         bool
+	skipPreflight bool
 }
 
 // NewResetCredentialsCommand creates a new command to delete a service account
@@ -92,6 +94,7 @@ func NewResetCredentialsCommand(f *factory.Factory) *cobra.Command {
 	cmd.Flags().StringVar(&opts.filename, "file-location", "", opts.localizer.LocalizeByID("serviceAccount.common.flag.fileLocation.description"))
 	cmd.Flags().StringVar(&opts.fileFormat, "file-format", "", opts.localizer.LocalizeByID("serviceAccount.common.flag.fileFormat.description"))
 	cmd.Flags().BoolVarP(&opts.force, "yes", "y", false, opts.localizer.LocalizeByID("serviceAccount.resetCredentials.flag.yes.description"))
+	preflight.AddFlag(cmd, &opts.skipPreflight, opts.localizer)
 
 	flagutil.EnableStaticFlagCompletion(cmd, "file-format", flagutil.CredentialsOutputFormats)
 
@@ -110,6 +113,12 @@ func runResetCredentials(opts *Options) (err error) {
 		return err
 	}
 
+	if !opts.skipPreflight {
+		if err = preflight.Check(connection, opts.localizer, preflight.UpdateServiceAccount); err != nil {
+			return err
+		}
+	}
+
 	api := connection.API()
 
 	serviceacct, _, err := api.ServiceAccount().GetServiceAccountById(context.Background(), opts.id).Execute()
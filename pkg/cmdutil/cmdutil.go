@@ -7,6 +7,7 @@ import (
 
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/aerogear/charmil-host-example/pkg/cloudprovider/cloudproviderutil"
+	"github.com/aerogear/charmil-host-example/pkg/cloudregion/cloudregionutil"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
 	"github.com/spf13/cobra"
@@ -147,3 +148,38 @@ func FetchCloudProviders(f *factory.Factory) (validProviders []string, directive
 
 	return validProviders, directive
 }
+
+// FetchCloudRegions returns the list of enabled regions of a cloud provider for creating a Kafka instance,
+// or the regions of all enabled cloud providers when provider is empty.
+// This is used in the cmd.RegisterFlagCompletionFunc for dynamic completion of --region
+func FetchCloudRegions(f *factory.Factory, provider string) (validRegions []string, directive cobra.ShellCompDirective) {
+	validRegions = []string{}
+	directive = cobra.ShellCompDirectiveNoSpace
+
+	conn, err := f.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return validRegions, directive
+	}
+
+	api := conn.API().Kafka()
+
+	cloudProviderResponse, _, err := api.GetCloudProviders(context.Background()).Execute()
+	if err != nil {
+		return validRegions, directive
+	}
+
+	for _, p := range cloudproviderutil.FilterEnabled(cloudProviderResponse.GetItems()) {
+		if provider != "" && p.GetName() != provider {
+			continue
+		}
+
+		cloudRegionResponse, _, err := api.GetCloudProviderRegions(context.Background(), p.GetId()).Execute()
+		if err != nil {
+			return validRegions, directive
+		}
+
+		validRegions = append(validRegions, cloudregionutil.GetEnabledIDs(cloudRegionResponse.GetItems())...)
+	}
+
+	return validRegions, directive
+}
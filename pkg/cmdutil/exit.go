@@ -0,0 +1,27 @@
+package cmdutil
+
+import "errors"
+
+// ExitError is an error which makes the CLI exit with a specific non-zero code,
+// so that scripts can tell different failures apart
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the code the CLI exits with for an error, which is 1 unless the error is an ExitError
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
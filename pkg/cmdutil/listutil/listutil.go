@@ -0,0 +1,251 @@
+// Package listutil contains the sorting, column selection and pagination
+// behaviour shared by all list commands.
+//
+// Rows printed by list commands are structs whose fields have a `header` tag.
+// Fields are referenced by the name in their `json` tag, for example "--sort-by name".
+package listutil
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmd/flag"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
+	"github.com/aerogear/charmil-host-example/pkg/dump"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// sortTag is an optional field tag, `sort:"desc"` reverses the order of a field when sorting
+	sortTag  = "sort"
+	sortDesc = "desc"
+)
+
+// Flags contains the values of the flags shared by list commands
+type Flags struct {
+	All     bool
+	SortBy  string
+	Columns []string
+}
+
+// AddPaginationFlags adds the --all flag to a list command whose API is paginated
+func AddPaginationFlags(cmd *cobra.Command, flags *Flags, localizer localize.Localizer) {
+	cmd.Flags().BoolVar(&flags.All, "all", false, localizer.LocalizeByID("list.flag.all.description"))
+}
+
+// AddTableFlags adds the --sort-by and --columns flags to a list command.
+// row is an instance of the row struct printed by the command and
+// defaultColumns are the columns printed when --columns is not set.
+func AddTableFlags(cmd *cobra.Command, flags *Flags, row interface{}, defaultColumns []string, localizer localize.Localizer) {
+	columns := ColumnNames(row)
+
+	cmd.Flags().StringVar(&flags.SortBy, "sort-by", "", localizer.LocalizeByID("list.flag.sortBy.description", localize.NewEntry("Columns", strings.Join(columns, ", "))))
+	cmd.Flags().StringSliceVar(&flags.Columns, "columns", defaultColumns, localizer.LocalizeByID("list.flag.columns.description", localize.NewEntry("Columns", strings.Join(columns, ", "))))
+
+	_ = cmd.RegisterFlagCompletionFunc("sort-by", func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return columns, cobra.ShellCompDirectiveNoSpace
+	})
+	_ = cmd.RegisterFlagCompletionFunc("columns", func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return columns, cobra.ShellCompDirectiveNoSpace
+	})
+}
+
+// Validate checks that --sort-by and --columns reference fields of the row struct
+func (f *Flags) Validate(row interface{}) error {
+	columns := ColumnNames(row)
+
+	if f.SortBy != "" && !flagutil.IsValidInput(f.SortBy, columns...) {
+		return flag.InvalidValueError("sort-by", f.SortBy, columns...)
+	}
+
+	for _, c := range f.Columns {
+		if !flagutil.IsValidInput(c, columns...) {
+			return flag.InvalidValueError("columns", c, columns...)
+		}
+	}
+
+	return nil
+}
+
+// Table prints the rows to a table with the columns selected by --columns,
+// or all columns when none have been selected
+func (f *Flags) Table(stream io.Writer, rows interface{}) error {
+	if len(f.Columns) == 0 {
+		dump.Table(stream, rows)
+		return nil
+	}
+
+	selected, err := SelectColumns(rows, f.Columns)
+	if err != nil {
+		return err
+	}
+
+	dump.Table(stream, selected)
+
+	return nil
+}
+
+// ColumnNames returns the names of all the fields of the row struct which can be printed
+func ColumnNames(row interface{}) []string {
+	rowType := reflect.TypeOf(row)
+
+	names := []string{}
+	for i := 0; i < rowType.NumField(); i++ {
+		if name, ok := columnName(rowType.Field(i)); ok {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// Sort sorts the slice of rows by the field with the given column name.
+// The same reordering is applied to each of the related slices, which must
+// have the same length as rows, so that API items can be kept in line with their rows.
+func Sort(rows interface{}, column string, related ...interface{}) error {
+	rowsValue := reflect.ValueOf(rows)
+	if rowsValue.Kind() != reflect.Slice {
+		return fmt.Errorf("rows must be a slice, got %v", rowsValue.Kind())
+	}
+
+	field, ok := fieldByColumn(rowsValue.Type().Elem(), column)
+	if !ok {
+		return fmt.Errorf("unknown column %q", column)
+	}
+
+	swappers := []func(i, j int){reflect.Swapper(rows)}
+	for _, r := range related {
+		if reflect.ValueOf(r).Len() != rowsValue.Len() {
+			return fmt.Errorf("related slice must have %v items", rowsValue.Len())
+		}
+		swappers = append(swappers, reflect.Swapper(r))
+	}
+
+	desc := field.Tag.Get(sortTag) == sortDesc
+
+	sort.Stable(&rowSorter{
+		n:        rowsValue.Len(),
+		swappers: swappers,
+		less: func(i, j int) bool {
+			a := rowsValue.Index(i).FieldByIndex(field.Index)
+			b := rowsValue.Index(j).FieldByIndex(field.Index)
+			if desc {
+				return lessValue(b, a)
+			}
+			return lessValue(a, b)
+		},
+	})
+
+	return nil
+}
+
+// SelectColumns returns a slice of rows containing only the given columns, in the given order.
+// The result can be printed with dump.Table.
+func SelectColumns(rows interface{}, columns []string) (interface{}, error) {
+	rowsValue := reflect.ValueOf(rows)
+	if rowsValue.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("rows must be a slice, got %v", rowsValue.Kind())
+	}
+
+	rowType := rowsValue.Type().Elem()
+
+	fields := make([]reflect.StructField, 0, len(columns))
+	for _, c := range columns {
+		field, ok := fieldByColumn(rowType, c)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", c)
+		}
+		fields = append(fields, reflect.StructField{
+			Name: field.Name,
+			Type: field.Type,
+			Tag:  field.Tag,
+		})
+	}
+
+	selectedType := reflect.StructOf(fields)
+	selected := reflect.MakeSlice(reflect.SliceOf(selectedType), rowsValue.Len(), rowsValue.Len())
+	for i := 0; i < rowsValue.Len(); i++ {
+		for j, f := range fields {
+			selected.Index(i).Field(j).Set(rowsValue.Index(i).FieldByName(f.Name))
+		}
+	}
+
+	return selected.Interface(), nil
+}
+
+// FetchAll calls fetch for every page, starting from the first one, until all items have been
+// retrieved. fetch returns the number of items on the page and the total number of items.
+func FetchAll(fetch func(page int32) (count int, total int, err error)) error {
+	fetched := 0
+	for page := int32(1); ; page++ {
+		count, total, err := fetch(page)
+		if err != nil {
+			return err
+		}
+
+		fetched += count
+		if count == 0 || fetched >= total {
+			return nil
+		}
+	}
+}
+
+type rowSorter struct {
+	n        int
+	less     func(i, j int) bool
+	swappers []func(i, j int)
+}
+
+func (s *rowSorter) Len() int           { return s.n }
+func (s *rowSorter) Less(i, j int) bool { return s.less(i, j) }
+func (s *rowSorter) Swap(i, j int) {
+	for _, swap := range s.swappers {
+		swap(i, j)
+	}
+}
+
+// columnName returns the column name of a struct field which has a table header
+func columnName(field reflect.StructField) (string, bool) {
+	if _, ok := field.Tag.Lookup("header"); !ok {
+		return "", false
+	}
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		name = field.Name
+	}
+
+	return name, true
+}
+
+func fieldByColumn(rowType reflect.Type, column string) (reflect.StructField, bool) {
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		if name, ok := columnName(field); ok && name == column {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func lessValue(a reflect.Value, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	case reflect.String:
+		return strings.ToLower(a.String()) < strings.ToLower(b.String())
+	default:
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	}
+}
@@ -0,0 +1,159 @@
+package listutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testRow struct {
+	Name   string `json:"name" header:"Name"`
+	Count  int    `json:"count,omitempty" header:"Count"`
+	Age    int64  `json:"age" header:"Age" sort:"desc"`
+	Hidden string `json:"hidden"`
+}
+
+func TestColumnNames(t *testing.T) {
+	want := []string{"name", "count", "age"}
+	if got := ColumnNames(testRow{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ColumnNames() = %v, want %v", got, want)
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		column    string
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "Should sort strings case-insensitively",
+			column:    "name",
+			wantNames: []string{"alpha", "Bravo", "charlie"},
+		},
+		{
+			name:      "Should sort numbers in ascending order",
+			column:    "count",
+			wantNames: []string{"alpha", "charlie", "Bravo"},
+		},
+		{
+			name:      "Should sort in descending order when the field has the desc sort tag",
+			column:    "age",
+			wantNames: []string{"charlie", "Bravo", "alpha"},
+		},
+		{
+			name:    "Should fail on an unknown column",
+			column:  "hidden",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			rows := []testRow{
+				{Name: "charlie", Count: 2, Age: 3},
+				{Name: "alpha", Count: 1, Age: 1},
+				{Name: "Bravo", Count: 3, Age: 2},
+			}
+			related := []string{"charlie", "alpha", "Bravo"}
+
+			err := Sort(rows, tt.column, related)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gotNames := []string{}
+			for _, r := range rows {
+				gotNames = append(gotNames, r.Name)
+			}
+			if !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("Sort() = %v, want %v", gotNames, tt.wantNames)
+			}
+			if !reflect.DeepEqual(related, tt.wantNames) {
+				t.Errorf("Sort() related = %v, want %v", related, tt.wantNames)
+			}
+		})
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	rows := []testRow{{Name: "alpha", Count: 1, Age: 5}}
+
+	got, err := SelectColumns(rows, []string{"age", "name"})
+	if err != nil {
+		t.Fatalf("SelectColumns() error = %v", err)
+	}
+
+	selected := reflect.ValueOf(got)
+	if selected.Len() != 1 {
+		t.Fatalf("SelectColumns() returned %v rows, want 1", selected.Len())
+	}
+
+	row := selected.Index(0)
+	if row.NumField() != 2 {
+		t.Fatalf("SelectColumns() returned %v fields, want 2", row.NumField())
+	}
+	if row.Field(0).Int() != 5 || row.Field(1).String() != "alpha" {
+		t.Errorf("SelectColumns() = %+v, want {Age:5 Name:alpha}", row.Interface())
+	}
+
+	if _, err = SelectColumns(rows, []string{"unknown"}); err == nil {
+		t.Error("SelectColumns() expected an error for an unknown column")
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageSizes []int
+		total     int
+		fetchErr  error
+		wantPages int
+	}{
+		{
+			name:      "Should fetch pages until the total is reached",
+			pageSizes: []int{2, 2, 1},
+			total:     5,
+			wantPages: 3,
+		},
+		{
+			name:      "Should stop on an empty page",
+			pageSizes: []int{2, 0},
+			total:     5,
+			wantPages: 2,
+		},
+		{
+			name:      "Should return the fetch error",
+			pageSizes: []int{2},
+			total:     5,
+			fetchErr:  errors.New("fetch failed"),
+			wantPages: 1,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			pages := 0
+			err := FetchAll(func(page int32) (int, int, error) {
+				pages++
+				if int(page) != pages {
+					t.Fatalf("FetchAll() fetched page %v, want %v", page, pages)
+				}
+				if tt.fetchErr != nil {
+					return 0, 0, tt.fetchErr
+				}
+				return tt.pageSizes[page-1], tt.total, nil
+			})
+			if !errors.Is(err, tt.fetchErr) {
+				t.Fatalf("FetchAll() error = %v, wantErr %v", err, tt.fetchErr)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("FetchAll() fetched %v pages, want %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Package parallel runs batches of independent requests with bounded concurrency.
+package parallel
+
+import "sync"
+
+// DefaultConcurrency is the number of requests run at the same time by bulk commands
+const DefaultConcurrency = 5
+
+// Run calls fn for each index in [0, n) with at most concurrency calls running at the same time.
+// It waits for all calls to finish and returns their errors by index, nil entries meaning success.
+func Run(n int, concurrency int, fn func(i int) error) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	errs := make([]error, n)
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
+
+// CountFailed returns the number of non-nil errors
+func CountFailed(errs []error) int {
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+package parallel
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		n           int
+		concurrency int
+	}{
+		{
+			name:        "Should run every call with bounded concurrency",
+			n:           20,
+			concurrency: 3,
+		},
+		{
+			name:        "Should run calls one at a time when the concurrency is invalid",
+			n:           5,
+			concurrency: 0,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			running, maxRunning := 0, 0
+			wantMax := tt.concurrency
+			if wantMax < 1 {
+				wantMax = 1
+			}
+
+			errs := Run(tt.n, tt.concurrency, func(i int) error {
+				mu.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mu.Unlock()
+
+				defer func() {
+					mu.Lock()
+					running--
+					mu.Unlock()
+				}()
+
+				if i%2 == 1 {
+					return errors.New("failed")
+				}
+				return nil
+			})
+
+			if len(errs) != tt.n {
+				t.Fatalf("Run() returned %v errors, want %v", len(errs), tt.n)
+			}
+			if maxRunning > wantMax {
+				t.Errorf("Run() ran %v calls at the same time, want at most %v", maxRunning, wantMax)
+			}
+			for i, err := range errs {
+				if (err != nil) != (i%2 == 1) {
+					t.Errorf("Run() error %v = %v", i, err)
+				}
+			}
+			if got, want := CountFailed(errs), tt.n/2; got != want {
+				t.Errorf("CountFailed() = %v, want %v", got, want)
+			}
+		})
+	}
+}
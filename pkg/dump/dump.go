@@ -1,8 +1,9 @@
-// Package dump contains functions used to print documents to JSON, YAML and Table formats
+// Package dump contains functions used to print documents to JSON, YAML, CSV and Table formats
 package dump
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -21,6 +22,7 @@ const (
 	JSONFormat = "json"
 	YAMLFormat = "yaml"
 	YMLFormat  = "yml"
+	CSVFormat  = "csv"
 )
 
 // JSON dumps the given data to the given stream so that it looks pretty. If the data is a valid
@@ -67,6 +69,16 @@ func Table(stream io.Writer, in interface{}) {
 	printer.Print(in)
 }
 
+// CSV prints the given records to the given stream as comma-separated values.
+// The first record is usually the header row.
+func CSV(stream io.Writer, records [][]string) error {
+	w := csv.NewWriter(stream)
+	if err := w.WriteAll(records); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
 func dumpBytes(stream io.Writer, data []byte) error {
 	_, err := stream.Write(data)
 	if err != nil {
@@ -0,0 +1,229 @@
+// Package acl builds and formats the access control list bindings of a Kafka instance
+package acl
+
+import (
+	"fmt"
+	"strings"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// Types of resources which bindings give access to
+const (
+	ResourceTypeTopic           = "topic"
+	ResourceTypeGroup           = "group"
+	ResourceTypeCluster         = "cluster"
+	ResourceTypeTransactionalID = "transactional-id"
+)
+
+// Types of patterns matching the names of resources
+const (
+	PatternTypeLiteral = "literal"
+	PatternTypePrefix  = "prefix"
+)
+
+// Permissions of bindings
+const (
+	PermissionAllow = "allow"
+	PermissionDeny  = "deny"
+)
+
+// Operations of bindings
+const (
+	OperationAll             = "all"
+	OperationRead            = "read"
+	OperationWrite           = "write"
+	OperationCreate          = "create"
+	OperationDelete          = "delete"
+	OperationAlter           = "alter"
+	OperationDescribe        = "describe"
+	OperationDescribeConfigs = "describe-configs"
+	OperationAlterConfigs    = "alter-configs"
+)
+
+// Presets of the operations granted by the convenience flags
+const (
+	PresetProducer = "producer"
+	PresetConsumer = "consumer"
+)
+
+const (
+	// ClusterResourceName is the name of the cluster resource of a Kafka instance
+	ClusterResourceName = "kafka-cluster"
+	// AllAccountsPrincipal is the principal matching every user and service account
+	AllAccountsPrincipal = "User:*"
+
+	principalPrefix = "User:"
+)
+
+var (
+	ValidResourceTypes = []string{ResourceTypeTopic, ResourceTypeGroup, ResourceTypeCluster, ResourceTypeTransactionalID}
+	ValidPatternTypes  = []string{PatternTypeLiteral, PatternTypePrefix}
+	ValidPermissions   = []string{PermissionAllow, PermissionDeny}
+	ValidOperations    = []string{
+		OperationAll, OperationRead, OperationWrite, OperationCreate, OperationDelete,
+		OperationAlter, OperationDescribe, OperationDescribeConfigs, OperationAlterConfigs,
+	}
+)
+
+// operationsByResourceType are the operations which apply to each type of resource
+var operationsByResourceType = map[string][]string{
+	ResourceTypeTopic: {
+		OperationAll, OperationRead, OperationWrite, OperationCreate, OperationDelete,
+		OperationAlter, OperationDescribe, OperationDescribeConfigs, OperationAlterConfigs,
+	},
+	ResourceTypeGroup:           {OperationAll, OperationRead, OperationDelete, OperationDescribe},
+	ResourceTypeCluster:         {OperationAll, OperationCreate, OperationAlter, OperationDescribe, OperationDescribeConfigs, OperationAlterConfigs},
+	ResourceTypeTransactionalID: {OperationAll, OperationWrite, OperationDescribe},
+}
+
+// presetOperations are the operations granted by a preset for each type of resource,
+// matching the --producer and --consumer options of the kafka-acls tool
+var presetOperations = map[string]map[string][]string{
+	PresetProducer: {
+		ResourceTypeTopic:           {OperationWrite, OperationDescribe, OperationCreate},
+		ResourceTypeTransactionalID: {OperationWrite, OperationDescribe},
+	},
+	PresetConsumer: {
+		ResourceTypeTopic: {OperationRead, OperationDescribe},
+		ResourceTypeGroup: {OperationRead},
+	},
+}
+
+// Resource is a resource, or the resources whose names start with Name for PatternTypePrefix
+type Resource struct {
+	Type        string
+	Name        string
+	PatternType string
+}
+
+// Binding is an ACL binding as printed by the CLI
+type Binding struct {
+	Principal    string `json:"principal" yaml:"principal" header:"Principal"`
+	Permission   string `json:"permission" yaml:"permission" header:"Permission"`
+	Operation    string `json:"operation" yaml:"operation" header:"Operation"`
+	ResourceType string `json:"resourceType" yaml:"resourceType" header:"Resource type"`
+	ResourceName string `json:"resourceName" yaml:"resourceName" header:"Resource name"`
+	PatternType  string `json:"patternType" yaml:"patternType" header:"Pattern type"`
+}
+
+// ServiceAccountPrincipal returns the principal of a service account or user
+func ServiceAccountPrincipal(id string) string {
+	return principalPrefix + id
+}
+
+// PrincipalName returns the service account or user of a principal, or "*" for all accounts
+func PrincipalName(principal string) string {
+	return strings.TrimPrefix(principal, principalPrefix)
+}
+
+// IsValidOperation checks if an operation applies to a type of resource
+func IsValidOperation(resourceType string, operation string) bool {
+	for _, o := range operationsByResourceType[resourceType] {
+		if o == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// PresetOperations returns the operations granted by a preset on a type of resource
+func PresetOperations(preset string, resourceType string) []string {
+	return presetOperations[preset][resourceType]
+}
+
+// NewBindings returns a binding for each operation on the resource
+func NewBindings(principal string, resource Resource, operations []string, permission string) ([]kafkainstanceclient.AclBinding, error) {
+	bindings := make([]kafkainstanceclient.AclBinding, 0, len(operations))
+
+	for _, operation := range operations {
+		if !IsValidOperation(resource.Type, operation) {
+			return nil, fmt.Errorf("operation %q does not apply to %v resources, valid operations are: %v",
+				operation, resource.Type, strings.Join(operationsByResourceType[resource.Type], ", "))
+		}
+
+		bindings = append(bindings, *kafkainstanceclient.NewAclBinding(
+			kafkainstanceclient.AclResourceType(toAPIValue(resource.Type)),
+			resource.Name,
+			patternTypeToAPI(resource.PatternType),
+			principal,
+			kafkainstanceclient.AclOperation(toAPIValue(operation)),
+			kafkainstanceclient.AclPermissionType(toAPIValue(permission)),
+		))
+	}
+
+	return bindings, nil
+}
+
+// FromAPI converts the bindings returned by the API to the format printed by the CLI
+func FromAPI(bindings []kafkainstanceclient.AclBinding) []Binding {
+	converted := make([]Binding, len(bindings))
+
+	for i, b := range bindings {
+		converted[i] = Binding{
+			Principal:    PrincipalName(b.GetPrincipal()),
+			Permission:   fromAPIValue(string(b.GetPermission())),
+			Operation:    fromAPIValue(string(b.GetOperation())),
+			ResourceType: fromAPIValue(string(b.GetResourceType())),
+			ResourceName: b.GetResourceName(),
+			PatternType:  patternTypeFromAPI(b.GetPatternType()),
+		}
+	}
+
+	return converted
+}
+
+// ResourceTypeFilter returns the API filter of a type of resource, matching any type when it is empty
+func ResourceTypeFilter(resourceType string) kafkainstanceclient.AclResourceTypeFilter {
+	if resourceType == "" {
+		return kafkainstanceclient.ACLRESOURCETYPEFILTER_ANY
+	}
+	return kafkainstanceclient.AclResourceTypeFilter(toAPIValue(resourceType))
+}
+
+// PatternTypeFilter returns the API filter of a type of pattern, matching any type when it is empty
+func PatternTypeFilter(patternType string) kafkainstanceclient.AclPatternTypeFilter {
+	if patternType == "" {
+		return kafkainstanceclient.ACLPATTERNTYPEFILTER_ANY
+	}
+	return kafkainstanceclient.AclPatternTypeFilter(patternTypeToAPI(patternType))
+}
+
+// OperationFilter returns the API filter of an operation, matching any operation when it is empty
+func OperationFilter(operation string) kafkainstanceclient.AclOperationFilter {
+	if operation == "" {
+		return kafkainstanceclient.ACLOPERATIONFILTER_ANY
+	}
+	return kafkainstanceclient.AclOperationFilter(toAPIValue(operation))
+}
+
+// PermissionFilter returns the API filter of a permission, matching any permission when it is empty
+func PermissionFilter(permission string) kafkainstanceclient.AclPermissionTypeFilter {
+	if permission == "" {
+		return kafkainstanceclient.ACLPERMISSIONTYPEFILTER_ANY
+	}
+	return kafkainstanceclient.AclPermissionTypeFilter(toAPIValue(permission))
+}
+
+// toAPIValue converts a flag value such as "describe-configs" to the API enum value "DESCRIBE_CONFIGS"
+func toAPIValue(value string) string {
+	return strings.ToUpper(strings.ReplaceAll(value, "-", "_"))
+}
+
+func fromAPIValue(value string) string {
+	return strings.ToLower(strings.ReplaceAll(value, "_", "-"))
+}
+
+func patternTypeToAPI(patternType string) kafkainstanceclient.AclPatternType {
+	if patternType == PatternTypePrefix {
+		return kafkainstanceclient.ACLPATTERNTYPE_PREFIXED
+	}
+	return kafkainstanceclient.ACLPATTERNTYPE_LITERAL
+}
+
+func patternTypeFromAPI(patternType kafkainstanceclient.AclPatternType) string {
+	if patternType == kafkainstanceclient.ACLPATTERNTYPE_PREFIXED {
+		return PatternTypePrefix
+	}
+	return PatternTypeLiteral
+}
@@ -0,0 +1,128 @@
+package acl
+
+import (
+	"reflect"
+	"testing"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestNewBindings(t *testing.T) {
+	principal := ServiceAccountPrincipal("srvc-acct-1")
+
+	tests := []struct {
+		name       string
+		resource   Resource
+		operations []string
+		permission string
+		want       []kafkainstanceclient.AclBinding
+		wantErr    bool
+	}{
+		{
+			name:       "consumer of topics with a prefix",
+			resource:   Resource{Type: ResourceTypeTopic, Name: "orders-", PatternType: PatternTypePrefix},
+			operations: PresetOperations(PresetConsumer, ResourceTypeTopic),
+			permission: PermissionAllow,
+			want: []kafkainstanceclient.AclBinding{
+				{ResourceType: "TOPIC", ResourceName: "orders-", PatternType: "PREFIXED", Principal: "User:srvc-acct-1", Operation: "READ", Permission: "ALLOW"},
+				{ResourceType: "TOPIC", ResourceName: "orders-", PatternType: "PREFIXED", Principal: "User:srvc-acct-1", Operation: "DESCRIBE", Permission: "ALLOW"},
+			},
+		},
+		{
+			name:       "deny describing the configs of the cluster",
+			resource:   Resource{Type: ResourceTypeCluster, Name: ClusterResourceName, PatternType: PatternTypeLiteral},
+			operations: []string{OperationDescribeConfigs},
+			permission: PermissionDeny,
+			want: []kafkainstanceclient.AclBinding{
+				{ResourceType: "CLUSTER", ResourceName: "kafka-cluster", PatternType: "LITERAL", Principal: "User:srvc-acct-1", Operation: "DESCRIBE_CONFIGS", Permission: "DENY"},
+			},
+		},
+		{
+			name:       "transactional ID",
+			resource:   Resource{Type: ResourceTypeTransactionalID, Name: "tx-1", PatternType: PatternTypeLiteral},
+			operations: PresetOperations(PresetProducer, ResourceTypeTransactionalID),
+			permission: PermissionAllow,
+			want: []kafkainstanceclient.AclBinding{
+				{ResourceType: "TRANSACTIONAL_ID", ResourceName: "tx-1", PatternType: "LITERAL", Principal: "User:srvc-acct-1", Operation: "WRITE", Permission: "ALLOW"},
+				{ResourceType: "TRANSACTIONAL_ID", ResourceName: "tx-1", PatternType: "LITERAL", Principal: "User:srvc-acct-1", Operation: "DESCRIBE", Permission: "ALLOW"},
+			},
+		},
+		{
+			name:       "operation which does not apply to groups",
+			resource:   Resource{Type: ResourceTypeGroup, Name: "group-1", PatternType: PatternTypeLiteral},
+			operations: []string{OperationWrite},
+			permission: PermissionAllow,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBindings(principal, tt.resource, tt.operations, tt.permission)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewBindings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromAPI(t *testing.T) {
+	bindings := []kafkainstanceclient.AclBinding{
+		{ResourceType: "TOPIC", ResourceName: "orders-", PatternType: "PREFIXED", Principal: "User:srvc-acct-1", Operation: "ALTER_CONFIGS", Permission: "DENY"},
+		{ResourceType: "GROUP", ResourceName: "*", PatternType: "LITERAL", Principal: AllAccountsPrincipal, Operation: "READ", Permission: "ALLOW"},
+	}
+
+	want := []Binding{
+		{Principal: "srvc-acct-1", Permission: "deny", Operation: "alter-configs", ResourceType: "topic", ResourceName: "orders-", PatternType: "prefix"},
+		{Principal: "*", Permission: "allow", Operation: "read", ResourceType: "group", ResourceName: "*", PatternType: "literal"},
+	}
+
+	if got := FromAPI(bindings); !reflect.DeepEqual(got, want) {
+		t.Errorf("FromAPI() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	if got := ResourceTypeFilter(""); got != kafkainstanceclient.ACLRESOURCETYPEFILTER_ANY {
+		t.Errorf("ResourceTypeFilter() = %v, want ANY", got)
+	}
+	if got := ResourceTypeFilter(ResourceTypeTransactionalID); got != kafkainstanceclient.ACLRESOURCETYPEFILTER_TRANSACTIONAL_ID {
+		t.Errorf("ResourceTypeFilter() = %v, want TRANSACTIONAL_ID", got)
+	}
+	if got := PatternTypeFilter(PatternTypePrefix); got != kafkainstanceclient.ACLPATTERNTYPEFILTER_PREFIXED {
+		t.Errorf("PatternTypeFilter() = %v, want PREFIXED", got)
+	}
+	if got := OperationFilter(OperationDescribeConfigs); got != kafkainstanceclient.ACLOPERATIONFILTER_DESCRIBE_CONFIGS {
+		t.Errorf("OperationFilter() = %v, want DESCRIBE_CONFIGS", got)
+	}
+	if got := PermissionFilter(""); got != kafkainstanceclient.ACLPERMISSIONTYPEFILTER_ANY {
+		t.Errorf("PermissionFilter() = %v, want ANY", got)
+	}
+}
+
+func TestPrincipalFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		flags         PrincipalFlags
+		wantPrincipal string
+		wantCount     int
+	}{
+		{name: "none", flags: PrincipalFlags{}, wantPrincipal: "", wantCount: 0},
+		{name: "service account", flags: PrincipalFlags{ServiceAccount: "srvc-acct-1"}, wantPrincipal: "User:srvc-acct-1", wantCount: 1},
+		{name: "user", flags: PrincipalFlags{User: "dev-user"}, wantPrincipal: "User:dev-user", wantCount: 1},
+		{name: "all accounts", flags: PrincipalFlags{AllAccounts: true}, wantPrincipal: AllAccountsPrincipal, wantCount: 1},
+		{name: "several", flags: PrincipalFlags{ServiceAccount: "srvc-acct-1", AllAccounts: true}, wantPrincipal: AllAccountsPrincipal, wantCount: 2},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			principal, count := tt.flags.Principal()
+			if principal != tt.wantPrincipal || count != tt.wantCount {
+				t.Errorf("Principal() = %q, %v, want %q, %v", principal, count, tt.wantPrincipal, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package acl
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aerogear/charmil/core/utils/localize"
+)
+
+// APIError maps the HTTP status code of a failed ACL request to a localized error
+func APIError(localizer localize.Localizer, httpRes *http.Response, err error, instanceName string, operation string) error {
+	if httpRes == nil {
+		return err
+	}
+
+	operationTmplPair := localize.NewEntry("Operation", operation)
+	switch httpRes.StatusCode {
+	case 400:
+		return errors.New(localizer.LocalizeByID("kafka.acl.common.error.badRequest", localize.NewEntry("ErrorMessage", err)))
+	case 401:
+		return errors.New(localizer.LocalizeByID("kafka.acl.common.error.unauthorized", operationTmplPair))
+	case 403:
+		return errors.New(localizer.LocalizeByID("kafka.acl.common.error.forbidden", operationTmplPair))
+	case 500:
+		return errors.New(localizer.LocalizeByID("kafka.acl.common.error.internalServerError"))
+	case 503:
+		return errors.New(localizer.LocalizeByID("kafka.acl.common.error.unableToConnectToKafka", localize.NewEntry("Name", instanceName)))
+	default:
+		return err
+	}
+}
@@ -0,0 +1,112 @@
+package acl
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+const fetchAllPageSize = 100
+
+// PrincipalFlags holds the values of the flags which select the principal of bindings
+type PrincipalFlags struct {
+	ServiceAccount string
+	User           string
+	AllAccounts    bool
+}
+
+// Principal returns the principal selected by the flags, or an empty string when none is set,
+// and the number of flags which are set
+func (f PrincipalFlags) Principal() (principal string, count int) {
+	if f.ServiceAccount != "" {
+		principal = ServiceAccountPrincipal(f.ServiceAccount)
+		count++
+	}
+	if f.User != "" {
+		principal = ServiceAccountPrincipal(f.User)
+		count++
+	}
+	if f.AllAccounts {
+		principal = AllAccountsPrincipal
+		count++
+	}
+	return principal, count
+}
+
+// Filter selects bindings, empty fields matching any value
+type Filter struct {
+	Principal    string
+	ResourceType string
+	ResourceName string
+	PatternType  string
+	Operation    string
+	Permission   string
+}
+
+// IsEmpty checks if the filter matches every binding
+func (f Filter) IsEmpty() bool {
+	return f == Filter{}
+}
+
+// FetchAll fetches every page of the bindings matching the filter
+func FetchAll(ctx context.Context, api *kafkainstanceclient.APIClient, filter Filter) ([]kafkainstanceclient.AclBinding, *http.Response, error) {
+	bindings := []kafkainstanceclient.AclBinding{}
+	var httpRes *http.Response
+
+	err := listutil.FetchAll(func(page int32) (int, int, error) {
+		data, res, err := List(ctx, api, filter, page, fetchAllPageSize)
+		httpRes = res
+		if res != nil {
+			defer res.Body.Close()
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		bindings = append(bindings, data.GetItems()...)
+		return len(data.GetItems()), int(data.GetTotal()), nil
+	})
+
+	return bindings, httpRes, err
+}
+
+// List fetches a page of the bindings matching the filter
+func List(ctx context.Context, api *kafkainstanceclient.APIClient, filter Filter, page int32, size int32) (kafkainstanceclient.AclBindingListPage, *http.Response, error) {
+	req := api.AclsApi.GetAcls(ctx).
+		ResourceType(ResourceTypeFilter(filter.ResourceType)).
+		PatternType(PatternTypeFilter(filter.PatternType)).
+		Operation(OperationFilter(filter.Operation)).
+		Permission(PermissionFilter(filter.Permission)).
+		Page(float32(page)).
+		Size(float32(size))
+
+	if filter.Principal != "" {
+		req = req.Principal(filter.Principal)
+	}
+	if filter.ResourceName != "" {
+		req = req.ResourceName(filter.ResourceName)
+	}
+
+	return req.Execute()
+}
+
+// Delete deletes the bindings matching the filter and returns them
+func Delete(ctx context.Context, api *kafkainstanceclient.APIClient, filter Filter) ([]kafkainstanceclient.AclBinding, *http.Response, error) {
+	req := api.AclsApi.DeleteAcls(ctx).
+		ResourceType(ResourceTypeFilter(filter.ResourceType)).
+		PatternType(PatternTypeFilter(filter.PatternType)).
+		Operation(OperationFilter(filter.Operation)).
+		Permission(PermissionFilter(filter.Permission))
+
+	if filter.Principal != "" {
+		req = req.Principal(filter.Principal)
+	}
+	if filter.ResourceName != "" {
+		req = req.ResourceName(filter.ResourceName)
+	}
+
+	deleted, httpRes, err := req.Execute()
+	return deleted.GetItems(), httpRes, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/aerogear/charmil-host-example/pkg/api/kas"
 	"github.com/aerogear/charmil-host-example/pkg/kafka/kafkaerr"
@@ -22,6 +23,17 @@ func GetKafkaByID(ctx context.Context, api kafkamgmtclient.DefaultApi, id string
 }
 
 func GetKafkaByName(ctx context.Context, api kafkamgmtclient.DefaultApi, name string) (*kafkamgmtclient.KafkaRequest, *http.Response, error) {
+	kafkaReq, httpResponse, err := FindKafkaByName(ctx, api, name)
+	if err == nil && kafkaReq == nil {
+		return nil, nil, kafkaerr.NotFoundByNameError(name, suggestKafkaNames(ctx, api, name)...)
+	}
+
+	return kafkaReq, httpResponse, err
+}
+
+// FindKafkaByName looks up a Kafka instance by name like GetKafkaByName,
+// but returns nil without an error and without looking up similar names when it does not exist
+func FindKafkaByName(ctx context.Context, api kafkamgmtclient.DefaultApi, name string) (*kafkamgmtclient.KafkaRequest, *http.Response, error) {
 	r := api.GetKafkas(ctx)
 	r = r.Search(fmt.Sprintf("name = %v", name))
 	kafkaList, httpResponse, err := r.Execute()
@@ -29,12 +41,52 @@ func GetKafkaByName(ctx context.Context, api kafkamgmtclient.DefaultApi, name st
 		return nil, httpResponse, err
 	}
 
-	if kafkaList.GetTotal() == 0 {
-		return nil, nil, kafkaerr.NotFoundByNameError(name)
+	items := kafkaList.GetItems()
+	if len(items) == 0 {
+		return nil, httpResponse, nil
+	}
+
+	if len(items) > 1 {
+		ids := make([]string, 0, len(items))
+		for _, k := range items {
+			ids = append(ids, k.GetId())
+		}
+		return nil, httpResponse, kafkaerr.AmbiguousNameError(name, ids)
 	}
 
-	items := kafkaList.GetItems()
 	kafkaReq := items[0]
 
 	return &kafkaReq, httpResponse, err
 }
+
+// GetKafkaByIDOrName looks up a Kafka instance by name, or by ID when no instance has that name
+func GetKafkaByIDOrName(ctx context.Context, api kafkamgmtclient.DefaultApi, nameOrID string) (*kafkamgmtclient.KafkaRequest, *http.Response, error) {
+	kafkaReq, httpResponse, err := GetKafkaByName(ctx, api, nameOrID)
+	if !kafkaerr.IsNameNotFound(err) {
+		return kafkaReq, httpResponse, err
+	}
+
+	nameErr := err
+	kafkaReq, httpResponse, err = GetKafkaByID(ctx, api, nameOrID)
+	if err != nil {
+		return nil, nil, nameErr
+	}
+
+	return kafkaReq, httpResponse, nil
+}
+
+// suggestKafkaNames returns the names of existing Kafka instances which are similar to name.
+// Suggestions are best-effort, so API errors are ignored.
+func suggestKafkaNames(ctx context.Context, api kafkamgmtclient.DefaultApi, name string) []string {
+	kafkaList, _, err := api.GetKafkas(ctx).Size(strconv.Itoa(maxSuggestionCandidates)).Execute()
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(kafkaList.GetItems()))
+	for _, k := range kafkaList.GetItems() {
+		names = append(names, k.GetName())
+	}
+
+	return SuggestNames(name, names)
+}
@@ -0,0 +1,146 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/pkg/kafka/kafkaerr"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// newKafkaListServer serves the Kafka instances with the given IDs and names,
+// searched by name or looked up by ID
+func newKafkaListServer(instances map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if id := strings.TrimPrefix(r.URL.Path, "/api/kafkas_mgmt/v1/kafkas/"); id != r.URL.Path {
+			name, ok := instances[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]string{"kind": "Error", "code": "KAFKAS-MGMT-7"})
+				return
+			}
+			kafkaReq := kafkamgmtclient.KafkaRequest{}
+			kafkaReq.SetId(id)
+			kafkaReq.SetName(name)
+			_ = json.NewEncoder(w).Encode(kafkaReq)
+			return
+		}
+
+		search := r.URL.Query().Get("search")
+		items := []kafkamgmtclient.KafkaRequest{}
+		for id, name := range instances {
+			if search == "" || search == "name = "+name {
+				kafkaReq := kafkamgmtclient.KafkaRequest{}
+				kafkaReq.SetId(id)
+				kafkaReq.SetName(name)
+				items = append(items, kafkaReq)
+			}
+		}
+
+		list := kafkamgmtclient.KafkaRequestList{}
+		list.SetItems(items)
+		list.SetTotal(int32(len(items)))
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+}
+
+func TestGetKafkaByIDOrName(t *testing.T) {
+	server := newKafkaListServer(map[string]string{"c1": "my-kafka"})
+	defer server.Close()
+
+	api := newKafkaAPI(server.URL)
+
+	tests := []struct {
+		name         string
+		nameOrID     string
+		wantID       string
+		wantNotFound bool
+	}{
+		{
+			name:     "Should find an instance by name",
+			nameOrID: "my-kafka",
+			wantID:   "c1",
+		},
+		{
+			name:     "Should find an instance by ID when no instance has that name",
+			nameOrID: "c1",
+			wantID:   "c1",
+		},
+		{
+			name:         "Should return the name error when neither the name nor the ID exist",
+			nameOrID:     "my-kafk",
+			wantNotFound: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := GetKafkaByIDOrName(context.Background(), api, tt.nameOrID)
+			if tt.wantNotFound {
+				if !kafkaerr.IsNameNotFound(err) {
+					t.Fatalf("GetKafkaByIDOrName() error = %v, want a name not found error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetKafkaByIDOrName() error = %v", err)
+			}
+			if got.GetId() != tt.wantID {
+				t.Errorf("GetKafkaByIDOrName() ID = %v, want %v", got.GetId(), tt.wantID)
+			}
+		})
+	}
+}
+
+func TestFindKafkaByName(t *testing.T) {
+	server := newKafkaListServer(map[string]string{"c1": "my-kafka"})
+	defer server.Close()
+
+	// count the requests, as a missing name must not be followed by a lookup of similar names
+	requests := 0
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler.ServeHTTP(w, r)
+	})
+
+	api := newKafkaAPI(server.URL)
+
+	tests := []struct {
+		name      string
+		kafkaName string
+		wantID    string
+	}{
+		{
+			name:      "Should find an instance by name",
+			kafkaName: "my-kafka",
+			wantID:    "c1",
+		},
+		{
+			name:      "Should return nil when no instance has that name",
+			kafkaName: "my-kafk",
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			got, _, err := FindKafkaByName(context.Background(), api, tt.kafkaName)
+			if err != nil {
+				t.Fatalf("FindKafkaByName() error = %v", err)
+			}
+			if got.GetId() != tt.wantID {
+				t.Errorf("FindKafkaByName() ID = %v, want %v", got.GetId(), tt.wantID)
+			}
+			if requests != 1 {
+				t.Errorf("FindKafkaByName() sent %v requests, want 1", requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package consumergroup
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/aerogear/charmil/core/utils/localize"
+)
+
+// APIError maps the HTTP status code of a failed consumer group request to a localized error
+func APIError(localizer localize.Localizer, httpRes *http.Response, err error, id string, instanceName string, operation string) error {
+	if httpRes == nil {
+		return err
+	}
+
+	operationTmplPair := localize.NewEntry("Operation", operation)
+	switch httpRes.StatusCode {
+	case 401:
+		return errors.New(localizer.LocalizeByID("kafka.consumerGroup.common.error.unauthorized", operationTmplPair))
+	case 403:
+		return errors.New(localizer.LocalizeByID("kafka.consumerGroup.common.error.forbidden", operationTmplPair))
+	case 404:
+		return errors.New(localizer.LocalizeByID("kafka.consumerGroup.common.error.notFoundError", localize.NewEntry("ID", id), localize.NewEntry("InstanceName", instanceName)))
+	case 500:
+		return errors.New(localizer.LocalizeByID("kafka.consumerGroup.common.error.internalServerError"))
+	case 503:
+		return errors.New(localizer.LocalizeByID("kafka.consumerGroup.common.error.unableToConnectToKafka", localize.NewEntry("Name", instanceName)))
+	default:
+		return err
+	}
+}
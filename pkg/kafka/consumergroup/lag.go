@@ -0,0 +1,115 @@
+package consumergroup
+
+import (
+	"sort"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// Exit codes of the CLI when a consumer group exceeds its lag thresholds
+const (
+	ExitCodeMaxLagExceeded               = 2
+	ExitCodeMaxPartitionsWithLagExceeded = 3
+	ExitCodeLagThresholdsExceeded        = 4
+)
+
+// Names of the lag thresholds, matching the flags which set them
+const (
+	ThresholdMaxLag               = "max-lag"
+	ThresholdMaxPartitionsWithLag = "max-partitions-with-lag"
+)
+
+// NoThreshold disables a lag threshold
+const NoThreshold = -1
+
+// LagThresholds are the limits of the lag of a consumer group
+type LagThresholds struct {
+	// MaxLag is the highest lag allowed in any partition
+	MaxLag int
+	// MaxPartitionsWithLag is the highest number of partitions with lag allowed in a group
+	MaxPartitionsWithLag int
+}
+
+// LagViolation is a partition which broke a lag threshold
+type LagViolation struct {
+	ConsumerGroupID string `json:"groupId" header:"Consumer group ID"`
+	Topic           string `json:"topic" header:"Topic"`
+	Partition       int    `json:"partition" header:"Partition"`
+	OffsetLag       int    `json:"lag" header:"Offset lag"`
+	Threshold       string `json:"threshold" header:"Threshold"`
+}
+
+// IsSet checks if any threshold is enabled
+func (t LagThresholds) IsSet() bool {
+	return t.MaxLag != NoThreshold || t.MaxPartitionsWithLag != NoThreshold
+}
+
+// Check returns the partitions of the consumer group which break the thresholds.
+// When the group has more partitions with lag than allowed, all of its partitions with lag are returned.
+func (t LagThresholds) Check(group kafkainstanceclient.ConsumerGroup) []LagViolation {
+	violations := []LagViolation{}
+	consumers := group.GetConsumers()
+
+	newViolation := func(c kafkainstanceclient.Consumer, threshold string) LagViolation {
+		return LagViolation{
+			ConsumerGroupID: group.GetGroupId(),
+			Topic:           c.GetTopic(),
+			Partition:       int(c.GetPartition()),
+			OffsetLag:       int(c.GetLag()),
+			Threshold:       threshold,
+		}
+	}
+
+	if t.MaxLag != NoThreshold {
+		for _, c := range consumers {
+			if int(c.GetLag()) > t.MaxLag {
+				violations = append(violations, newViolation(c, ThresholdMaxLag))
+			}
+		}
+	}
+
+	if t.MaxPartitionsWithLag != NoThreshold && GetPartitionsWithLag(consumers) > t.MaxPartitionsWithLag {
+		for _, c := range consumers {
+			if c.GetLag() > 0 {
+				violations = append(violations, newViolation(c, ThresholdMaxPartitionsWithLag))
+			}
+		}
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.ConsumerGroupID != b.ConsumerGroupID {
+			return a.ConsumerGroupID < b.ConsumerGroupID
+		}
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Partition < b.Partition
+	})
+
+	return violations
+}
+
+// LagExitCode returns the exit code for the thresholds broken by the violations, or 0 when there are none
+func LagExitCode(violations []LagViolation) int {
+	var maxLag, maxPartitions bool
+	for _, v := range violations {
+		switch v.Threshold {
+		case ThresholdMaxLag:
+			maxLag = true
+		case ThresholdMaxPartitionsWithLag:
+			maxPartitions = true
+		}
+	}
+
+	switch {
+	case maxLag && maxPartitions:
+		return ExitCodeLagThresholdsExceeded
+	case maxLag:
+		return ExitCodeMaxLagExceeded
+	case maxPartitions:
+		return ExitCodeMaxPartitionsWithLagExceeded
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,48 @@
+package consumergroup
+
+import (
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestLagThresholds(t *testing.T) {
+	group := kafkainstanceclient.ConsumerGroup{
+		GroupId: "group-1",
+		Consumers: []kafkainstanceclient.Consumer{
+			mockutil.NewConsumerMock("orders", 1, "member-1", 50),
+			mockutil.NewConsumerMock("orders", 0, "member-1", 200),
+			mockutil.NewConsumerMock("orders", 2, "member-1", 0),
+		},
+	}
+
+	tests := []struct {
+		name           string
+		thresholds     LagThresholds
+		wantViolations int
+		wantExitCode   int
+	}{
+		{name: "within thresholds", thresholds: LagThresholds{MaxLag: 200, MaxPartitionsWithLag: 2}, wantViolations: 0, wantExitCode: 0},
+		{name: "max lag", thresholds: LagThresholds{MaxLag: 100, MaxPartitionsWithLag: NoThreshold}, wantViolations: 1, wantExitCode: ExitCodeMaxLagExceeded},
+		{name: "max partitions with lag", thresholds: LagThresholds{MaxLag: NoThreshold, MaxPartitionsWithLag: 1}, wantViolations: 2, wantExitCode: ExitCodeMaxPartitionsWithLagExceeded},
+		{name: "both", thresholds: LagThresholds{MaxLag: 0, MaxPartitionsWithLag: 0}, wantViolations: 4, wantExitCode: ExitCodeLagThresholdsExceeded},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			violations := tt.thresholds.Check(group)
+			if len(violations) != tt.wantViolations {
+				t.Errorf("Check() returned %v violations, want %v: %+v", len(violations), tt.wantViolations, violations)
+			}
+			if got := LagExitCode(violations); got != tt.wantExitCode {
+				t.Errorf("LagExitCode() = %v, want %v", got, tt.wantExitCode)
+			}
+		})
+	}
+
+	violations := LagThresholds{MaxLag: 10, MaxPartitionsWithLag: NoThreshold}.Check(group)
+	if violations[0].Partition != 0 || violations[1].Partition != 1 {
+		t.Errorf("Check() violations are not sorted by partition: %+v", violations)
+	}
+}
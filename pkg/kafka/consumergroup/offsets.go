@@ -0,0 +1,204 @@
+package consumergroup
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"gopkg.in/yaml.v2"
+)
+
+// OffsetExport is the committed offsets of consumer groups, as written by the export command
+type OffsetExport struct {
+	Instance string         `json:"instance,omitempty" yaml:"instance,omitempty"`
+	Groups   []GroupOffsets `json:"groups" yaml:"groups"`
+}
+
+// GroupOffsets is the committed offsets of a consumer group
+type GroupOffsets struct {
+	ID      string            `json:"id" yaml:"id"`
+	Offsets []CommittedOffset `json:"offsets" yaml:"offsets"`
+}
+
+// CommittedOffset is the offset committed by a consumer group for a partition
+type CommittedOffset struct {
+	Topic     string `json:"topic" yaml:"topic" header:"Topic"`
+	Partition int    `json:"partition" yaml:"partition" header:"Partition"`
+	Offset    int64  `json:"offset" yaml:"offset" header:"Offset"`
+}
+
+// OffsetOutOfRange is a committed offset outside of the offsets stored in its partition
+type OffsetOutOfRange struct {
+	ConsumerGroupID string `header:"Consumer group ID"`
+	Topic           string `header:"Topic"`
+	Partition       int    `header:"Partition"`
+	Offset          int64  `header:"Offset"`
+	Range           string `header:"Log range"`
+}
+
+// ExportOffsets returns the offsets committed by a consumer group, ordered by topic and partition.
+// committed holds the offsets of the partitions by topic, as read with messaging.Client.CommittedOffsets.
+func ExportOffsets(groupID string, committed map[string]map[int]int64) GroupOffsets {
+	exported := GroupOffsets{ID: groupID, Offsets: []CommittedOffset{}}
+
+	for topic, partitions := range committed {
+		for partition, offset := range partitions {
+			exported.Offsets = append(exported.Offsets, CommittedOffset{
+				Topic:     topic,
+				Partition: partition,
+				Offset:    offset,
+			})
+		}
+	}
+
+	sortOffsets(exported.Offsets)
+
+	return exported
+}
+
+// ReadOffsetExport reads an export of committed offsets.
+// JSON is a subset of YAML, so both formats are parsed the same way.
+func ReadOffsetExport(r io.Reader) (*OffsetExport, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var export OffsetExport
+	if err = yaml.UnmarshalStrict(data, &export); err != nil {
+		return nil, err
+	}
+
+	for _, g := range export.Groups {
+		if g.ID == "" {
+			return nil, fmt.Errorf("consumer group without an ID")
+		}
+		for _, o := range g.Offsets {
+			if o.Topic == "" || o.Partition < 0 || o.Offset < 0 {
+				return nil, fmt.Errorf("invalid offset %+v of consumer group %q", o, g.ID)
+			}
+		}
+	}
+
+	return &export, nil
+}
+
+// ParseTopicMapping parses topic mappings in the format "source=target"
+func ParseTopicMapping(values []string) (map[string]string, error) {
+	mapping := make(map[string]string, len(values))
+
+	for _, v := range values {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid topic mapping %q, use the format \"source=target\"", v)
+		}
+		mapping[parts[0]] = parts[1]
+	}
+
+	return mapping, nil
+}
+
+// MapTopics renames the topics of the offsets of each group, keeping topics without a mapping
+func (e *OffsetExport) MapTopics(mapping map[string]string) {
+	for i := range e.Groups {
+		offsets := e.Groups[i].Offsets
+		for j := range offsets {
+			if target, ok := mapping[offsets[j].Topic]; ok {
+				offsets[j].Topic = target
+			}
+		}
+		sortOffsets(offsets)
+	}
+}
+
+// Topics returns the sorted names of all topics with committed offsets
+func (e *OffsetExport) Topics() []string {
+	seen := map[string]bool{}
+	topics := []string{}
+
+	for _, g := range e.Groups {
+		for _, o := range g.Offsets {
+			if !seen[o.Topic] {
+				seen[o.Topic] = true
+				topics = append(topics, o.Topic)
+			}
+		}
+	}
+
+	sort.Strings(topics)
+
+	return topics
+}
+
+// CheckOffsetRanges returns the offsets which are not within the range of offsets of their partition,
+// including offsets of partitions which don't exist. ranges holds the offset ranges of the partitions by topic.
+func CheckOffsetRanges(group GroupOffsets, ranges map[string]map[int]messaging.OffsetRange) []OffsetOutOfRange {
+	outOfRange := []OffsetOutOfRange{}
+
+	for _, o := range group.Offsets {
+		r, ok := ranges[o.Topic][o.Partition]
+		if ok && o.Offset >= r.First && o.Offset <= r.Last {
+			continue
+		}
+
+		logRange := "-"
+		if ok {
+			logRange = fmt.Sprintf("%v-%v", r.First, r.Last)
+		}
+
+		outOfRange = append(outOfRange, OffsetOutOfRange{
+			ConsumerGroupID: group.ID,
+			Topic:           o.Topic,
+			Partition:       o.Partition,
+			Offset:          o.Offset,
+			Range:           logRange,
+		})
+	}
+
+	return outOfRange
+}
+
+// OffsetResetParameters returns the parameters of the requests which reset a consumer group to its offsets.
+// The API resets partitions to a single absolute offset, so partitions are grouped by topic and offset.
+func OffsetResetParameters(group GroupOffsets) []kafkainstanceclient.ConsumerGroupResetOffsetParameters {
+	type topicOffset struct {
+		topic  string
+		offset int64
+	}
+
+	order := []topicOffset{}
+	partitions := map[topicOffset][]int32{}
+	for _, o := range group.Offsets {
+		key := topicOffset{o.Topic, o.Offset}
+		if _, ok := partitions[key]; !ok {
+			order = append(order, key)
+		}
+		partitions[key] = append(partitions[key], int32(o.Partition))
+	}
+
+	params := make([]kafkainstanceclient.ConsumerGroupResetOffsetParameters, len(order))
+	for i, key := range order {
+		topic := kafkainstanceclient.NewTopicsToResetOffset(key.topic)
+		topic.SetPartitions(partitions[key])
+
+		p := kafkainstanceclient.NewConsumerGroupResetOffsetParameters(OffsetAbsolute)
+		p.SetValue(fmt.Sprint(key.offset))
+		p.SetTopics([]kafkainstanceclient.TopicsToResetOffset{*topic})
+		params[i] = *p
+	}
+
+	return params
+}
+
+func sortOffsets(offsets []CommittedOffset) {
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+}
@@ -0,0 +1,104 @@
+package consumergroup
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+)
+
+func TestExportOffsets(t *testing.T) {
+	// offsets from 2^24 cannot be represented exactly as 32-bit floats
+	committed := map[string]map[int]int64{
+		"payments": {0: 7},
+		"orders":   {1: 1<<24 + 1, 0: 10},
+	}
+
+	want := GroupOffsets{ID: "group-1", Offsets: []CommittedOffset{
+		{Topic: "orders", Partition: 0, Offset: 10},
+		{Topic: "orders", Partition: 1, Offset: 1<<24 + 1},
+		{Topic: "payments", Partition: 0, Offset: 7},
+	}}
+
+	if got := ExportOffsets("group-1", committed); !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportOffsets() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadOffsetExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *OffsetExport
+		wantErr bool
+	}{
+		{
+			name: "YAML",
+			data: "instance: source\ngroups:\n- id: group-1\n  offsets:\n  - topic: orders\n    partition: 0\n    offset: 10\n",
+			want: &OffsetExport{Instance: "source", Groups: []GroupOffsets{{ID: "group-1", Offsets: []CommittedOffset{{Topic: "orders", Partition: 0, Offset: 10}}}}},
+		},
+		{
+			name: "JSON",
+			data: `{"groups":[{"id":"group-1","offsets":[{"topic":"orders","partition":1,"offset":20}]}]}`,
+			want: &OffsetExport{Groups: []GroupOffsets{{ID: "group-1", Offsets: []CommittedOffset{{Topic: "orders", Partition: 1, Offset: 20}}}}},
+		},
+		{name: "unknown field", data: "groups: []\nextra: true\n", wantErr: true},
+		{name: "missing group ID", data: "groups:\n- offsets: []\n", wantErr: true},
+		{name: "negative offset", data: "groups:\n- id: g\n  offsets:\n  - topic: orders\n    partition: 0\n    offset: -1\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadOffsetExport(strings.NewReader(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadOffsetExport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadOffsetExport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapTopics(t *testing.T) {
+	mapping, err := ParseTopicMapping([]string{"orders=orders-v2"})
+	if err != nil {
+		t.Fatalf("ParseTopicMapping() error = %v", err)
+	}
+	if _, err = ParseTopicMapping([]string{"orders"}); err == nil {
+		t.Error("ParseTopicMapping() expected an error for a mapping without a target")
+	}
+
+	export := &OffsetExport{Groups: []GroupOffsets{{ID: "group-1", Offsets: []CommittedOffset{
+		{Topic: "orders", Partition: 0, Offset: 10},
+		{Topic: "audit", Partition: 0, Offset: 3},
+	}}}}
+	export.MapTopics(mapping)
+
+	if got, want := export.Topics(), []string{"audit", "orders-v2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Topics() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckOffsetRanges(t *testing.T) {
+	group := GroupOffsets{ID: "group-1", Offsets: []CommittedOffset{
+		{Topic: "orders", Partition: 0, Offset: 10},
+		{Topic: "orders", Partition: 1, Offset: 50},
+		{Topic: "orders", Partition: 2, Offset: 0},
+		{Topic: "missing", Partition: 0, Offset: 0},
+	}}
+	ranges := map[string]map[int]messaging.OffsetRange{
+		"orders": {0: {First: 0, Last: 10}, 1: {First: 0, Last: 40}},
+	}
+
+	want := []OffsetOutOfRange{
+		{ConsumerGroupID: "group-1", Topic: "orders", Partition: 1, Offset: 50, Range: "0-40"},
+		{ConsumerGroupID: "group-1", Topic: "orders", Partition: 2, Offset: 0, Range: "-"},
+		{ConsumerGroupID: "group-1", Topic: "missing", Partition: 0, Offset: 0, Range: "-"},
+	}
+
+	if got := CheckOffsetRanges(group, ranges); !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckOffsetRanges() = %+v, want %+v", got, want)
+	}
+}
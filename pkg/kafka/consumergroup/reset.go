@@ -0,0 +1,115 @@
+package consumergroup
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// Offsets which a consumer group can be reset to
+const (
+	OffsetEarliest  = "earliest"
+	OffsetLatest    = "latest"
+	OffsetAbsolute  = "absolute"
+	OffsetTimestamp = "timestamp"
+)
+
+// ValidResetOffsets are the valid values of the offset to reset a consumer group to
+var ValidResetOffsets = []string{OffsetEarliest, OffsetLatest, OffsetAbsolute, OffsetTimestamp}
+
+// PartitionOffset is the offset of a consumer group in a partition of a topic
+type PartitionOffset struct {
+	Topic     string
+	Partition int32
+	Offset    string
+}
+
+// FilterConsumersWithMember returns the consumers which are members of the group,
+// leaving out partitions which only have a committed offset
+func FilterConsumersWithMember(consumers []kafkainstanceclient.Consumer) []kafkainstanceclient.Consumer {
+	members := []kafkainstanceclient.Consumer{}
+	for _, c := range consumers {
+		if c.GetMemberId() != "" {
+			members = append(members, c)
+		}
+	}
+	return members
+}
+
+// CurrentOffsets returns the committed offsets of the partitions of a topic, sorted by partition.
+// committed holds the offsets of the partitions by topic, as read with messaging.Client.CommittedOffsets.
+// Only the requested partitions are returned when partitions are given,
+// and those which have no committed offset are included with an empty offset.
+func CurrentOffsets(committed map[string]map[int]int64, topic string, partitions []int32) []PartitionOffset {
+	offsets := []PartitionOffset{}
+	seen := map[int32]bool{}
+
+	for partition, offset := range committed[topic] {
+		p := int32(partition)
+		if len(partitions) > 0 && !containsPartition(partitions, p) {
+			continue
+		}
+		seen[p] = true
+		offsets = append(offsets, PartitionOffset{Topic: topic, Partition: p, Offset: fmt.Sprint(offset)})
+	}
+
+	for _, p := range partitions {
+		if !seen[p] {
+			seen[p] = true
+			offsets = append(offsets, PartitionOffset{Topic: topic, Partition: p})
+		}
+	}
+
+	sort.Slice(offsets, func(i, j int) bool {
+		return offsets[i].Partition < offsets[j].Partition
+	})
+
+	return offsets
+}
+
+// ExpectedResetOffset returns the offset a partition will be reset to when it is known without resetting it,
+// which is the log end offset for "latest" and the value for "absolute", or an empty string otherwise.
+// ranges holds the offset ranges of the partitions of the topic.
+func ExpectedResetOffset(ranges map[int]messaging.OffsetRange, offset PartitionOffset, to string, value string) string {
+	switch to {
+	case OffsetAbsolute:
+		return value
+	case OffsetLatest:
+		if r, ok := ranges[int(offset.Partition)]; ok {
+			return fmt.Sprint(r.Last)
+		}
+	}
+	return ""
+}
+
+// ResetOffsets returns the offsets of the result of a reset, sorted by topic and partition
+func ResetOffsets(result kafkainstanceclient.ConsumerGroupResetOffsetResult) []PartitionOffset {
+	offsets := []PartitionOffset{}
+	for _, item := range result.GetItems() {
+		offsets = append(offsets, PartitionOffset{
+			Topic:     item.GetTopic(),
+			Partition: item.GetPartition(),
+			Offset:    fmt.Sprintf("%v", item.GetOffset()),
+		})
+	}
+
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+
+	return offsets
+}
+
+func containsPartition(partitions []int32, partition int32) bool {
+	for _, p := range partitions {
+		if p == partition {
+			return true
+		}
+	}
+	return false
+}
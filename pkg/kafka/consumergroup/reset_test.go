@@ -0,0 +1,75 @@
+package consumergroup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	"github.com/aerogear/charmil-host-example/pkg/kafka/messaging"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestFilterConsumersWithMember(t *testing.T) {
+	consumers := []kafkainstanceclient.Consumer{
+		mockutil.NewConsumerMock("orders", 0, "member-1", 0),
+		mockutil.NewConsumerMock("orders", 1, "", 0),
+	}
+
+	if got := GetActiveConsumersCount(FilterConsumersWithMember(consumers)); got != 1 {
+		t.Errorf("GetActiveConsumersCount() = %v, want 1", got)
+	}
+	if got := GetActiveConsumersCount(FilterConsumersWithMember(consumers[1:])); got != 0 {
+		t.Errorf("GetActiveConsumersCount() = %v, want 0 for a group without members", got)
+	}
+}
+
+func TestCurrentOffsets(t *testing.T) {
+	// offsets from 2^24 cannot be represented exactly as 32-bit floats
+	committed := map[string]map[int]int64{
+		"orders":   {1: 1<<24 + 1, 0: 10},
+		"payments": {0: 5},
+	}
+
+	tests := []struct {
+		name       string
+		partitions []int32
+		want       []PartitionOffset
+	}{
+		{
+			name: "all partitions",
+			want: []PartitionOffset{
+				{Topic: "orders", Partition: 0, Offset: "10"},
+				{Topic: "orders", Partition: 1, Offset: "16777217"},
+			},
+		},
+		{
+			name:       "selected partitions",
+			partitions: []int32{1, 2},
+			want: []PartitionOffset{
+				{Topic: "orders", Partition: 1, Offset: "16777217"},
+				{Topic: "orders", Partition: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got := CurrentOffsets(committed, "orders", tt.partitions)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CurrentOffsets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	ranges := map[int]messaging.OffsetRange{0: {First: 0, Last: 1<<24 + 3}}
+	offset := PartitionOffset{Topic: "orders", Partition: 0}
+	if got := ExpectedResetOffset(ranges, offset, OffsetLatest, ""); got != "16777219" {
+		t.Errorf("ExpectedResetOffset(latest) = %q, want %q", got, "16777219")
+	}
+	if got := ExpectedResetOffset(ranges, offset, OffsetAbsolute, "12"); got != "12" {
+		t.Errorf("ExpectedResetOffset(absolute) = %q, want %q", got, "12")
+	}
+	if got := ExpectedResetOffset(ranges, offset, OffsetEarliest, ""); got != "" {
+		t.Errorf("ExpectedResetOffset(earliest) = %q, want an empty string", got)
+	}
+}
@@ -0,0 +1,118 @@
+package consumergroup
+
+import (
+	"sort"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// TopicSummary is the lag of a consumer group on one of its topics
+type TopicSummary struct {
+	Topic             string `json:"topic" yaml:"topic" header:"Topic"`
+	Partitions        int    `json:"partitions" yaml:"partitions" header:"Partitions"`
+	PartitionsWithLag int    `json:"partitionsWithLag" yaml:"partitionsWithLag" header:"Partitions with lag"`
+	TotalLag          int    `json:"totalLag" yaml:"totalLag" header:"Total lag"`
+	MaxLag            int    `json:"maxLag" yaml:"maxLag" header:"Max lag"`
+}
+
+// MemberSummary is the set of partitions assigned to a member of a consumer group
+type MemberSummary struct {
+	MemberID   string            `json:"memberId" yaml:"memberId"`
+	Partitions []MemberPartition `json:"partitions" yaml:"partitions"`
+	TotalLag   int               `json:"totalLag" yaml:"totalLag"`
+}
+
+// MemberPartition is a partition assigned to a member of a consumer group
+type MemberPartition struct {
+	Topic     string `json:"topic" yaml:"topic"`
+	Partition int    `json:"partition" yaml:"partition"`
+	Lag       int    `json:"lag" yaml:"lag"`
+}
+
+// SummarizeByTopic aggregates the lag of the consumers of a consumer group per topic, ordered by topic name
+func SummarizeByTopic(consumers []kafkainstanceclient.Consumer) []TopicSummary {
+	byTopic := map[string]*TopicSummary{}
+	topics := []string{}
+
+	for _, c := range consumers {
+		if c.GetPartition() == -1 {
+			continue
+		}
+
+		summary, ok := byTopic[c.GetTopic()]
+		if !ok {
+			summary = &TopicSummary{Topic: c.GetTopic()}
+			byTopic[c.GetTopic()] = summary
+			topics = append(topics, c.GetTopic())
+		}
+
+		summary.Partitions++
+		if lag := int(c.GetLag()); lag > 0 {
+			summary.PartitionsWithLag++
+			summary.TotalLag += lag
+			if lag > summary.MaxLag {
+				summary.MaxLag = lag
+			}
+		}
+	}
+
+	sort.Strings(topics)
+
+	summaries := make([]TopicSummary, len(topics))
+	for i, topic := range topics {
+		summaries[i] = *byTopic[topic]
+	}
+
+	return summaries
+}
+
+// SummarizeByMember groups the partitions of a consumer group by the member they are assigned to,
+// ordered by member ID. Partitions without a member are grouped under an empty member ID.
+func SummarizeByMember(consumers []kafkainstanceclient.Consumer) []MemberSummary {
+	byMember := map[string]*MemberSummary{}
+	members := []string{}
+
+	for _, c := range consumers {
+		if c.GetPartition() == -1 {
+			continue
+		}
+
+		summary, ok := byMember[c.GetMemberId()]
+		if !ok {
+			summary = &MemberSummary{MemberID: c.GetMemberId(), Partitions: []MemberPartition{}}
+			byMember[c.GetMemberId()] = summary
+			members = append(members, c.GetMemberId())
+		}
+
+		summary.Partitions = append(summary.Partitions, MemberPartition{
+			Topic:     c.GetTopic(),
+			Partition: int(c.GetPartition()),
+			Lag:       int(c.GetLag()),
+		})
+		if c.GetLag() > 0 {
+			summary.TotalLag += int(c.GetLag())
+		}
+	}
+
+	sort.Strings(members)
+
+	summaries := make([]MemberSummary, len(members))
+	for i, member := range members {
+		summary := byMember[member]
+		sort.Slice(summary.Partitions, func(i, j int) bool {
+			a, b := summary.Partitions[i], summary.Partitions[j]
+			if a.Topic != b.Topic {
+				return a.Topic < b.Topic
+			}
+			return a.Partition < b.Partition
+		})
+		summaries[i] = *summary
+	}
+
+	return summaries
+}
+
+// IsEmpty checks if a consumer group has no active members
+func IsEmpty(group kafkainstanceclient.ConsumerGroup) bool {
+	return GetActiveConsumersCount(FilterConsumersWithMember(group.GetConsumers())) == 0
+}
@@ -0,0 +1,71 @@
+package consumergroup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestSummarizeByTopic(t *testing.T) {
+	consumers := []kafkainstanceclient.Consumer{
+		mockutil.NewConsumerMock("payments", 0, "member-2", 0),
+		mockutil.NewConsumerMock("orders", 1, "member-1", 10),
+		mockutil.NewConsumerMock("orders", 0, "member-1", 30),
+		mockutil.NewConsumerMock("orders", 2, "", 0),
+		mockutil.NewConsumerMock("", -1, "member-3", 0),
+	}
+
+	want := []TopicSummary{
+		{Topic: "orders", Partitions: 3, PartitionsWithLag: 2, TotalLag: 40, MaxLag: 30},
+		{Topic: "payments", Partitions: 1},
+	}
+
+	if got := SummarizeByTopic(consumers); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeByTopic() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarizeByMember(t *testing.T) {
+	consumers := []kafkainstanceclient.Consumer{
+		mockutil.NewConsumerMock("payments", 0, "member-1", 5),
+		mockutil.NewConsumerMock("orders", 1, "member-1", 10),
+		mockutil.NewConsumerMock("orders", 0, "member-1", 30),
+		mockutil.NewConsumerMock("orders", 2, "", 3),
+	}
+
+	want := []MemberSummary{
+		{MemberID: "", TotalLag: 3, Partitions: []MemberPartition{{Topic: "orders", Partition: 2, Lag: 3}}},
+		{MemberID: "member-1", TotalLag: 45, Partitions: []MemberPartition{
+			{Topic: "orders", Partition: 0, Lag: 30},
+			{Topic: "orders", Partition: 1, Lag: 10},
+			{Topic: "payments", Partition: 0, Lag: 5},
+		}},
+	}
+
+	if got := SummarizeByMember(consumers); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeByMember() = %+v, want %+v", got, want)
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	tests := []struct {
+		name      string
+		consumers []kafkainstanceclient.Consumer
+		want      bool
+	}{
+		{name: "no consumers", consumers: []kafkainstanceclient.Consumer{}, want: true},
+		{name: "committed offsets without members", consumers: []kafkainstanceclient.Consumer{mockutil.NewConsumerMock("orders", 0, "", 5)}, want: true},
+		{name: "active member", consumers: []kafkainstanceclient.Consumer{mockutil.NewConsumerMock("orders", 0, "member-1", 5)}, want: false},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			group := kafkainstanceclient.ConsumerGroup{GroupId: "group-1", Consumers: tt.consumers}
+			if got := IsEmpty(group); got != tt.want {
+				t.Errorf("IsEmpty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,16 @@
 package consumergroup
 
 import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
 	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
 )
 
+const fetchAllPageSize = 100
+
 // GetPartitionsWithLag returns the number of partitions having lag for a consumer group
 func GetPartitionsWithLag(consumers []kafkainstanceclient.Consumer) (partitionsWithLag int) {
 	for _, consumer := range consumers {
@@ -32,3 +39,66 @@ func GetUnconsumedPartitions(consumers []kafkainstanceclient.Consumer) (unconsum
 	}
 	return unconsumedPartitions
 }
+
+// GetTotalLag returns the sum of the lag of all partitions of a consumer group
+func GetTotalLag(consumers []kafkainstanceclient.Consumer) (totalLag int) {
+	for _, c := range consumers {
+		if c.Lag > 0 {
+			totalLag += int(c.Lag)
+		}
+	}
+	return totalLag
+}
+
+// FilterConsumersByTopic returns the consumers of a consumer group which read from the topic
+func FilterConsumersByTopic(consumers []kafkainstanceclient.Consumer, topic string) []kafkainstanceclient.Consumer {
+	filtered := []kafkainstanceclient.Consumer{}
+	for _, c := range consumers {
+		if c.GetTopic() == topic {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// GetTopics returns the sorted names of the topics of the partitions of a consumer group
+func GetTopics(consumers []kafkainstanceclient.Consumer) []string {
+	seen := map[string]bool{}
+	topics := []string{}
+	for _, c := range consumers {
+		if c.GetPartition() == -1 || seen[c.GetTopic()] {
+			continue
+		}
+		seen[c.GetTopic()] = true
+		topics = append(topics, c.GetTopic())
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// FetchAllForTopic fetches every page of the consumer groups which consume from the topic,
+// or of all consumer groups when the topic is empty
+func FetchAllForTopic(ctx context.Context, api *kafkainstanceclient.APIClient, topic string) ([]kafkainstanceclient.ConsumerGroup, *http.Response, error) {
+	groups := []kafkainstanceclient.ConsumerGroup{}
+	var httpRes *http.Response
+
+	err := listutil.FetchAll(func(page int32) (int, int, error) {
+		req := api.GroupsApi.GetConsumerGroups(ctx)
+		if topic != "" {
+			req = req.Topic(topic)
+		}
+		data, res, err := req.Page(page).Size(fetchAllPageSize).Execute()
+		httpRes = res
+		if res != nil {
+			defer res.Body.Close()
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		groups = append(groups, data.GetItems()...)
+		return len(data.GetItems()), int(data.GetTotal()), nil
+	})
+
+	return groups, httpRes, err
+}
@@ -0,0 +1,45 @@
+package consumergroup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/internal/mockutil"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestConsumersOfTopic(t *testing.T) {
+	consumers := []kafkainstanceclient.Consumer{
+		mockutil.NewConsumerMock("orders", 0, "member-1", 5),
+		mockutil.NewConsumerMock("orders", 1, "", 10),
+		mockutil.NewConsumerMock("payments", 0, "member-1", 7),
+	}
+
+	filtered := FilterConsumersByTopic(consumers, "orders")
+	if len(filtered) != 2 {
+		t.Fatalf("FilterConsumersByTopic() returned %v consumers, want 2", len(filtered))
+	}
+
+	if got := GetTotalLag(filtered); got != 15 {
+		t.Errorf("GetTotalLag() = %v, want 15", got)
+	}
+	if got := GetPartitionsWithLag(filtered); got != 2 {
+		t.Errorf("GetPartitionsWithLag() = %v, want 2", got)
+	}
+	if got := GetUnconsumedPartitions(filtered); got != 1 {
+		t.Errorf("GetUnconsumedPartitions() = %v, want 1", got)
+	}
+}
+
+func TestGetTopics(t *testing.T) {
+	consumers := []kafkainstanceclient.Consumer{
+		{Topic: "payments", Partition: 0},
+		{Topic: "orders", Partition: 1},
+		{Topic: "orders", Partition: 0},
+		{Topic: "", Partition: -1},
+	}
+
+	if got, want := GetTopics(consumers), []string{"orders", "payments"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTopics() = %v, want %v", got, want)
+	}
+}
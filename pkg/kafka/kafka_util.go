@@ -7,7 +7,10 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/aerogear/charmil-host-example/pkg/cloudprovider/cloudproviderutil"
+	"github.com/aerogear/charmil-host-example/pkg/cloudregion/cloudregionutil"
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
+	flagutil "github.com/aerogear/charmil-host-example/pkg/cmdutil/flags"
 	"github.com/aerogear/charmil-host-example/pkg/common/commonerr"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
 	"github.com/aerogear/charmil-host-example/pkg/kafka/kafkaerr"
@@ -116,3 +119,39 @@ func (v *Validator) ValidateNameIsAvailable(val interface{}) error {
 
 	return nil
 }
+
+// ValidateCloudProviderRegion checks that the cloud provider and one of its regions
+// are enabled for creating Kafka instances
+func (v *Validator) ValidateCloudProviderRegion(provider string, region string) error {
+	connection, err := v.Connection(connection.DefaultConfigSkipMasAuth)
+	if err != nil {
+		return err
+	}
+
+	api := connection.API()
+
+	cloudProviderResponse, _, err := api.Kafka().GetCloudProviders(context.Background()).Execute()
+	if err != nil {
+		return err
+	}
+
+	cloudProviders := cloudProviderResponse.GetItems()
+	providerNames := cloudproviderutil.GetEnabledNames(cloudProviders)
+	if !flagutil.IsValidInput(provider, providerNames...) {
+		return errors.New(v.Localizer.LocalizeByID("kafka.validation.error.invalidProvider", localize.NewEntry("Provider", provider), localize.NewEntry("ValidProviders", strings.Join(providerNames, ", "))))
+	}
+
+	selectedCloudProvider := cloudproviderutil.FindByName(cloudProviders, provider)
+
+	cloudRegionResponse, _, err := api.Kafka().GetCloudProviderRegions(context.Background(), selectedCloudProvider.GetId()).Execute()
+	if err != nil {
+		return err
+	}
+
+	regionIDs := cloudregionutil.GetEnabledIDs(cloudRegionResponse.GetItems())
+	if !flagutil.IsValidInput(region, regionIDs...) {
+		return errors.New(v.Localizer.LocalizeByID("kafka.validation.error.invalidRegion", localize.NewEntry("Region", region), localize.NewEntry("Provider", provider), localize.NewEntry("ValidRegions", strings.Join(regionIDs, ", "))))
+	}
+
+	return nil
+}
@@ -1,12 +1,15 @@
 package kafkaerr
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
 	NotFoundByIDErr         error
 	NotFoundByNameErr       error
+	AmbiguousNameErr        error
 	IllegalSearchValueError error
 	InvalidNameErr          error
 )
@@ -16,11 +19,38 @@ func NotFoundByIDError(id string) error {
 	return NotFoundByIDErr
 }
 
-func NotFoundByNameError(name string) error {
-	NotFoundByNameErr = fmt.Errorf(`Kafka instance "%v" not found`, name)
+// NameNotFoundError is the error for a Kafka instance name which does not exist
+type NameNotFoundError struct {
+	Name        string
+	Suggestions []string
+}
+
+func (e *NameNotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf(`Kafka instance "%v" not found`, e.Name)
+	}
+	return fmt.Sprintf(`Kafka instance "%v" not found, did you mean %v?`, e.Name, quoteAll(e.Suggestions, " or "))
+}
+
+// NotFoundByNameError returns the error for a Kafka instance name which does not exist,
+// suggesting the given similar names, if any
+func NotFoundByNameError(name string, suggestions ...string) error {
+	NotFoundByNameErr = &NameNotFoundError{Name: name, Suggestions: suggestions}
 	return NotFoundByNameErr
 }
 
+// IsNameNotFound checks if err is, or wraps, a NameNotFoundError
+func IsNameNotFound(err error) bool {
+	var notFound *NameNotFoundError
+	return errors.As(err, &notFound)
+}
+
+// AmbiguousNameError returns the error for a Kafka instance name which matches several instances
+func AmbiguousNameError(name string, ids []string) error {
+	AmbiguousNameErr = fmt.Errorf(`multiple Kafka instances are named "%v" (IDs: %v), use the "--id" flag to select one`, name, strings.Join(ids, ", "))
+	return AmbiguousNameErr
+}
+
 func InvalidSearchValueError(v string) error {
 	IllegalSearchValueError = fmt.Errorf(`
 	illegal search value "%v", search input must satisfy the following conditions:
@@ -42,3 +72,11 @@ func InvalidNameError(v string) error {
 	`, v)
 	return InvalidNameErr
 }
+
+func quoteAll(values []string, sep string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, sep)
+}
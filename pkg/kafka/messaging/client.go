@@ -0,0 +1,467 @@
+// Package messaging produces and consumes the messages of Kafka topics
+// using the Kafka protocol, authenticating with SASL/OAUTHBEARER.
+package messaging
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"golang.org/x/oauth2"
+)
+
+const (
+	fetchMaxBytes = 1024 * 1024
+	fetchMaxWait  = 500 * time.Millisecond
+	// idleBackoff is how long to wait before fetching again when no partition returned any records
+	idleBackoff = 200 * time.Millisecond
+	dialTimeout = 10 * time.Second
+)
+
+// Config holds the connection settings of a client
+type Config struct {
+	// Bootstrap is the host and port of the bootstrap server
+	Bootstrap string
+	// TLS is the TLS configuration, or nil to connect without TLS
+	TLS *tls.Config
+	// TokenSource gives the access token used to authenticate, or nil to connect without authentication
+	TokenSource oauth2.TokenSource
+}
+
+// Client produces and consumes messages
+type Client struct {
+	client    *kafka.Client
+	transport *kafka.Transport
+}
+
+// ConsumeOptions defines where to start consuming and when to stop
+type ConsumeOptions struct {
+	Topic string
+	// Partitions to consume from, all partitions when empty
+	Partitions []int
+	// Offset to start from in every partition: FirstOffset, LastOffset or an absolute offset
+	Offset int64
+	// Timestamp to start from, which takes precedence over the offset when set
+	Timestamp time.Time
+	// MaxMessages stops consuming after this many messages, or never when 0
+	MaxMessages int
+}
+
+// NewClient creates a client for the Kafka instance of the config
+func NewClient(cfg Config) *Client {
+	transport := &kafka.Transport{
+		TLS:         cfg.TLS,
+		DialTimeout: dialTimeout,
+		ClientID:    "rhoas",
+	}
+	if cfg.TokenSource != nil {
+		transport.SASL = &oauthBearer{tokens: cfg.TokenSource}
+	}
+
+	return &Client{
+		client: &kafka.Client{
+			Addr:      kafka.TCP(cfg.Bootstrap),
+			Transport: transport,
+		},
+		transport: transport,
+	}
+}
+
+// Close closes the connections of the client
+func (c *Client) Close() {
+	c.transport.CloseIdleConnections()
+}
+
+// Partitions returns the sorted IDs of the partitions of a topic
+func (c *Client) Partitions(ctx context.Context, topic string) ([]int, error) {
+	res, err := c.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range res.Topics {
+		if t.Name != topic {
+			continue
+		}
+		if t.Error != nil {
+			return nil, t.Error
+		}
+
+		partitions := make([]int, len(t.Partitions))
+		for i, p := range t.Partitions {
+			partitions[i] = p.ID
+		}
+		sort.Ints(partitions)
+		return partitions, nil
+	}
+
+	return nil, kafka.UnknownTopicOrPartition
+}
+
+// IsUnknownTopic checks if an error is caused by a topic or partition which doesn't exist
+func IsUnknownTopic(err error) bool {
+	return errors.Is(err, kafka.UnknownTopicOrPartition)
+}
+
+// OffsetRange is the range of offsets of the messages stored in a partition
+type OffsetRange struct {
+	// First is the offset of the earliest message
+	First int64
+	// Last is the offset after the latest message, where the next message will be written
+	Last int64
+}
+
+// OffsetRanges returns the range of offsets of each partition of a topic
+func (c *Client) OffsetRanges(ctx context.Context, topic string) (map[int]OffsetRange, error) {
+	partitions, err := c.Partitions(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]kafka.OffsetRequest, 0, 2*len(partitions))
+	for _, partition := range partitions {
+		requests = append(requests, kafka.FirstOffsetOf(partition), kafka.LastOffsetOf(partition))
+	}
+
+	res, err := c.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{topic: requests},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make(map[int]OffsetRange, len(partitions))
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+		ranges[p.Partition] = OffsetRange{First: p.FirstOffset, Last: p.LastOffset}
+	}
+
+	return ranges, nil
+}
+
+// CommittedOffsets returns the offsets committed by a consumer group for the partitions of the topics, by topic.
+// Partitions without a committed offset and topics which don't exist are left out.
+func (c *Client) CommittedOffsets(ctx context.Context, groupID string, topics []string) (map[string]map[int]int64, error) {
+	partitions := make(map[string][]int, len(topics))
+	for _, topic := range topics {
+		ids, err := c.Partitions(ctx, topic)
+		if IsUnknownTopic(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		partitions[topic] = ids
+	}
+
+	if len(partitions) == 0 {
+		return map[string]map[int]int64{}, nil
+	}
+
+	res, err := c.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{GroupID: groupID, Topics: partitions})
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	offsets := make(map[string]map[int]int64, len(res.Topics))
+	for topic, partitions := range res.Topics {
+		for _, p := range partitions {
+			if p.Error != nil {
+				return nil, p.Error
+			}
+			// a partition without a committed offset is returned with offset -1
+			if p.CommittedOffset < 0 {
+				continue
+			}
+			if offsets[topic] == nil {
+				offsets[topic] = map[int]int64{}
+			}
+			offsets[topic][p.Partition] = p.CommittedOffset
+		}
+	}
+
+	return offsets, nil
+}
+
+// Produce sends messages to a topic and returns them with the partition and offset they were written to.
+// Messages without a partition are sent to the partition of their key, or spread over all partitions when they have no key.
+func (c *Client) Produce(ctx context.Context, topic string, messages []Message) ([]Message, error) {
+	partitions, err := c.Partitions(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	// messages without a partition cannot be assigned one when the metadata lists none
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf(`topic "%v" has no partitions`, topic)
+	}
+
+	balancer := &kafka.Murmur2Balancer{}
+	next := 0
+
+	// messages are grouped by partition, keeping their order within each partition
+	order := []int{}
+	batches := map[int][]int{}
+	for i := range messages {
+		msg := &messages[i]
+		msg.Topic = topic
+
+		if msg.Partition == AnyPartition {
+			if msg.Key != nil {
+				msg.Partition = balancer.Balance(kafka.Message{Key: msg.Key}, partitions...)
+			} else {
+				msg.Partition = partitions[next%len(partitions)]
+				next++
+			}
+		}
+
+		if _, ok := batches[msg.Partition]; !ok {
+			order = append(order, msg.Partition)
+		}
+		batches[msg.Partition] = append(batches[msg.Partition], i)
+	}
+
+	for _, partition := range order {
+		indexes := batches[partition]
+
+		records := make([]kafka.Record, len(indexes))
+		for i, index := range indexes {
+			records[i] = toRecord(messages[index])
+		}
+
+		res, err := c.client.Produce(ctx, &kafka.ProduceRequest{
+			Topic:        topic,
+			Partition:    partition,
+			RequiredAcks: kafka.RequireAll,
+			Records:      kafka.NewRecordReader(records...),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if res.Error != nil {
+			return nil, res.Error
+		}
+		for _, recordErr := range res.RecordErrors {
+			return nil, recordErr
+		}
+
+		for i, index := range indexes {
+			messages[index].Offset = res.BaseOffset + int64(i)
+		}
+	}
+
+	return messages, nil
+}
+
+// Consume fetches messages from the partitions of a topic and passes them to the handler
+// until the maximum number of messages is reached or the context is done.
+func (c *Client) Consume(ctx context.Context, opts ConsumeOptions, handle func(Message) error) error {
+	partitions := opts.Partitions
+	if len(partitions) == 0 {
+		var err error
+		if partitions, err = c.Partitions(ctx, opts.Topic); err != nil {
+			return err
+		}
+	}
+
+	offsets, err := c.startOffsets(ctx, opts, partitions)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for {
+		received := false
+
+		for _, partition := range partitions {
+			res, err := c.client.Fetch(ctx, &kafka.FetchRequest{
+				Topic:     opts.Topic,
+				Partition: partition,
+				Offset:    offsets[partition],
+				MinBytes:  1,
+				MaxBytes:  fetchMaxBytes,
+				MaxWait:   fetchMaxWait,
+			})
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if res.Error != nil {
+				return res.Error
+			}
+
+			for {
+				record, err := res.Records.ReadRecord()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+
+				// a fetch returns whole batches, which may start before the requested offset
+				if record.Offset < offsets[partition] {
+					continue
+				}
+
+				msg, err := toMessage(opts.Topic, partition, record)
+				if err != nil {
+					return err
+				}
+				if err = handle(msg); err != nil {
+					return err
+				}
+
+				offsets[partition] = record.Offset + 1
+				received = true
+
+				count++
+				if opts.MaxMessages > 0 && count >= opts.MaxMessages {
+					return nil
+				}
+			}
+		}
+
+		if !received {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(idleBackoff):
+			}
+		}
+	}
+}
+
+// startOffsets resolves the offset of each partition to start consuming from
+func (c *Client) startOffsets(ctx context.Context, opts ConsumeOptions, partitions []int) (map[int]int64, error) {
+	offsets := make(map[int]int64, len(partitions))
+
+	if opts.Timestamp.IsZero() && opts.Offset >= 0 {
+		for _, partition := range partitions {
+			offsets[partition] = opts.Offset
+		}
+		return offsets, nil
+	}
+
+	requests := make([]kafka.OffsetRequest, len(partitions))
+	for i, partition := range partitions {
+		switch {
+		case !opts.Timestamp.IsZero():
+			requests[i] = kafka.TimeOffsetOf(partition, opts.Timestamp)
+		case opts.Offset == FirstOffset:
+			requests[i] = kafka.FirstOffsetOf(partition)
+		default:
+			requests[i] = kafka.LastOffsetOf(partition)
+		}
+	}
+
+	res, err := c.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{opts.Topic: requests},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range res.Topics[opts.Topic] {
+		if p.Error != nil {
+			return nil, p.Error
+		}
+
+		switch {
+		case opts.Timestamp.IsZero() && opts.Offset == FirstOffset:
+			offsets[p.Partition] = p.FirstOffset
+		case opts.Timestamp.IsZero():
+			offsets[p.Partition] = p.LastOffset
+		default:
+			// when no message is at or after the timestamp, the offset is -1,
+			// and the partition is resolved with the missing ones below
+			for offset := range p.Offsets {
+				if offset >= 0 {
+					offsets[p.Partition] = offset
+				}
+			}
+		}
+	}
+
+	// partitions without an offset for the timestamp are consumed from their end
+	var missing []int
+	for _, partition := range partitions {
+		if _, ok := offsets[partition]; !ok {
+			missing = append(missing, partition)
+		}
+	}
+	if len(missing) > 0 {
+		latest, err := c.startOffsets(ctx, ConsumeOptions{Topic: opts.Topic, Offset: LastOffset}, missing)
+		if err != nil {
+			return nil, err
+		}
+		for partition, offset := range latest {
+			offsets[partition] = offset
+		}
+	}
+
+	return offsets, nil
+}
+
+func toRecord(msg Message) kafka.Record {
+	record := kafka.Record{
+		Time:  msg.Time,
+		Value: kafka.NewBytes(msg.Value),
+	}
+	if msg.Key != nil {
+		record.Key = kafka.NewBytes(msg.Key)
+	}
+	for _, h := range msg.Headers {
+		record.Headers = append(record.Headers, kafka.Header{Key: h.Key, Value: []byte(h.Value)})
+	}
+	return record
+}
+
+func toMessage(topic string, partition int, record *kafka.Record) (Message, error) {
+	msg := Message{
+		Topic:     topic,
+		Partition: partition,
+		Offset:    record.Offset,
+		Time:      record.Time,
+	}
+
+	var err error
+	if msg.Key, err = readBytes(record.Key); err != nil {
+		return msg, err
+	}
+	if msg.Value, err = readBytes(record.Value); err != nil {
+		return msg, err
+	}
+	for _, h := range record.Headers {
+		msg.Headers = append(msg.Headers, Header{Key: h.Key, Value: string(h.Value)})
+	}
+
+	return msg, nil
+}
+
+// readBytes reads the content of a key or value, which is nil when it is missing
+func readBytes(b kafka.Bytes) ([]byte, error) {
+	if b == nil {
+		return nil, nil
+	}
+	defer b.Close()
+
+	data, err := ioutil.ReadAll(b)
+	if err != nil {
+		return nil, fmt.Errorf("could not read record: %w", err)
+	}
+	return data, nil
+}
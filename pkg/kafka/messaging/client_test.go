@@ -0,0 +1,264 @@
+package messaging
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const testToken = "test-token"
+
+func newTestClient(t *testing.T, broker *fakeBroker, token string) *Client {
+	c := NewClient(Config{
+		Bootstrap:   broker.addr(),
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+	})
+	t.Cleanup(c.Close)
+	return c
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func consumeAll(t *testing.T, c *Client, opts ConsumeOptions) []Message {
+	var messages []Message
+	err := c.Consume(testContext(t), opts, func(msg Message) error {
+		messages = append(messages, msg)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	return messages
+}
+
+func TestProduceAndConsume(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"orders": 1})
+	c := newTestClient(t, broker, testToken)
+
+	produced, err := c.Produce(testContext(t), "orders", []Message{
+		{Partition: AnyPartition, Key: []byte("k1"), Value: []byte("first"), Headers: []Header{{Key: "source", Value: "test"}}},
+		{Partition: AnyPartition, Value: []byte("second")},
+	})
+	if err != nil {
+		t.Fatalf("Produce() error = %v", err)
+	}
+	if produced[0].Offset != 0 || produced[1].Offset != 1 {
+		t.Errorf("Produce() offsets = %v, %v, want 0, 1", produced[0].Offset, produced[1].Offset)
+	}
+
+	messages := consumeAll(t, c, ConsumeOptions{Topic: "orders", Offset: FirstOffset, MaxMessages: 2})
+	if len(messages) != 2 {
+		t.Fatalf("Consume() got %v messages, want 2", len(messages))
+	}
+
+	first := messages[0]
+	if string(first.Key) != "k1" || string(first.Value) != "first" || first.Offset != 0 {
+		t.Errorf("first message = %+v", first)
+	}
+	if !reflect.DeepEqual(first.Headers, []Header{{Key: "source", Value: "test"}}) {
+		t.Errorf("first message headers = %v", first.Headers)
+	}
+	if messages[1].Key != nil || string(messages[1].Value) != "second" {
+		t.Errorf("second message = %+v", messages[1])
+	}
+}
+
+func TestConsumeFromOffsetAndTimestamp(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 1})
+	c := newTestClient(t, broker, testToken)
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	var input []Message
+	for i := 0; i < 5; i++ {
+		input = append(input, Message{Partition: 0, Value: []byte{byte('a' + i)}, Time: start.Add(time.Duration(i) * time.Minute)})
+	}
+	if _, err := c.Produce(testContext(t), "events", input); err != nil {
+		t.Fatalf("Produce() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		opts ConsumeOptions
+		want string
+	}{
+		{name: "absolute offset", opts: ConsumeOptions{Offset: 3}, want: "de"},
+		{name: "timestamp", opts: ConsumeOptions{Timestamp: start.Add(90 * time.Second)}, want: "cde"},
+		{name: "max messages", opts: ConsumeOptions{Offset: FirstOffset, MaxMessages: 2}, want: "ab"},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Topic = "events"
+			if tt.opts.MaxMessages == 0 {
+				tt.opts.MaxMessages = len(tt.want)
+			}
+
+			var got string
+			for _, msg := range consumeAll(t, c, tt.opts) {
+				got += string(msg.Value)
+			}
+			if got != tt.want {
+				t.Errorf("Consume() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsumeLatestWaitsForNewMessages(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 2})
+	c := newTestClient(t, broker, testToken)
+
+	if _, err := c.Produce(testContext(t), "events", []Message{{Partition: 0, Value: []byte("old")}}); err != nil {
+		t.Fatalf("Produce() error = %v", err)
+	}
+
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		_, _ = c.Produce(context.Background(), "events", []Message{{Partition: 1, Value: []byte("new")}})
+	}()
+
+	messages := consumeAll(t, c, ConsumeOptions{Topic: "events", Offset: LastOffset, MaxMessages: 1})
+	if len(messages) != 1 || string(messages[0].Value) != "new" || messages[0].Partition != 1 {
+		t.Errorf("Consume() = %+v, want the new message", messages)
+	}
+}
+
+func TestConsumeStopsWhenContextIsDone(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 1})
+	c := newTestClient(t, broker, testToken)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	err := c.Consume(ctx, ConsumeOptions{Topic: "events", Offset: FirstOffset}, func(Message) error {
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Consume() error = %v, want nil", err)
+	}
+}
+
+func TestProduceSpreadsMessagesOverPartitions(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 3})
+	c := newTestClient(t, broker, testToken)
+
+	var input []Message
+	for i := 0; i < 6; i++ {
+		input = append(input, Message{Partition: AnyPartition, Value: []byte("v")})
+	}
+	input = append(input, Message{Partition: AnyPartition, Key: []byte("same"), Value: []byte("k")}, Message{Partition: AnyPartition, Key: []byte("same"), Value: []byte("k")})
+
+	produced, err := c.Produce(testContext(t), "events", input)
+	if err != nil {
+		t.Fatalf("Produce() error = %v", err)
+	}
+
+	for p := 0; p < 3; p++ {
+		if n := len(broker.records("events", p)); n < 2 {
+			t.Errorf("partition %v has %v records, want at least 2", p, n)
+		}
+	}
+	if produced[6].Partition != produced[7].Partition {
+		t.Errorf("messages with the same key were sent to partitions %v and %v", produced[6].Partition, produced[7].Partition)
+	}
+}
+
+func TestInvalidToken(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 1})
+	c := newTestClient(t, broker, "wrong-token")
+
+	if _, err := c.Produce(testContext(t), "events", []Message{{Partition: AnyPartition, Value: []byte("v")}}); err == nil {
+		t.Error("Produce() expected an authentication error")
+	}
+}
+
+func TestUnknownTopic(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 1})
+	c := newTestClient(t, broker, testToken)
+
+	if _, err := c.Partitions(testContext(t), "missing"); !IsUnknownTopic(err) {
+		t.Errorf("Partitions() error = %v, want an unknown topic error", err)
+	}
+}
+
+func TestOffsetRanges(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"orders": 2})
+	c := newTestClient(t, broker, testToken)
+
+	_, err := c.Produce(testContext(t), "orders", []Message{
+		{Partition: 0, Value: []byte("first")},
+		{Partition: 0, Value: []byte("second")},
+		{Partition: 1, Value: []byte("third")},
+	})
+	if err != nil {
+		t.Fatalf("Produce() error = %v", err)
+	}
+
+	ranges, err := c.OffsetRanges(testContext(t), "orders")
+	if err != nil {
+		t.Fatalf("OffsetRanges() error = %v", err)
+	}
+
+	want := map[int]OffsetRange{0: {First: 0, Last: 2}, 1: {First: 0, Last: 1}}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("OffsetRanges() = %+v, want %+v", ranges, want)
+	}
+}
+
+func TestCommittedOffsets(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"orders": 2, "payments": 1})
+	c := newTestClient(t, broker, testToken)
+
+	// offsets from 2^24 cannot be represented exactly as 32-bit floats
+	broker.commit("group-1", "orders", 0, 1<<24+1)
+	broker.commit("group-1", "orders", 1, 7)
+	broker.commit("group-1", "payments", 0, 123456789012)
+	broker.commit("group-2", "orders", 0, 3)
+
+	offsets, err := c.CommittedOffsets(testContext(t), "group-1", []string{"orders", "payments", "missing"})
+	if err != nil {
+		t.Fatalf("CommittedOffsets() error = %v", err)
+	}
+
+	want := map[string]map[int]int64{
+		"orders":   {0: 1<<24 + 1, 1: 7},
+		"payments": {0: 123456789012},
+	}
+	if !reflect.DeepEqual(offsets, want) {
+		t.Errorf("CommittedOffsets() = %+v, want %+v", offsets, want)
+	}
+}
+
+func TestStartOffsetsAfterLastTimestamp(t *testing.T) {
+	broker := newFakeBroker(t, testToken, map[string]int{"events": 2})
+	c := newTestClient(t, broker, testToken)
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	_, err := c.Produce(testContext(t), "events", []Message{
+		{Partition: 0, Value: []byte("a"), Time: start},
+		{Partition: 0, Value: []byte("b"), Time: start.Add(time.Minute)},
+		{Partition: 1, Value: []byte("c"), Time: start.Add(2 * time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("Produce() error = %v", err)
+	}
+
+	// partition 0 has no message at or after the timestamp, so it starts at its end
+	opts := ConsumeOptions{Topic: "events", Timestamp: start.Add(90 * time.Second)}
+	offsets, err := c.startOffsets(testContext(t), opts, []int{0, 1})
+	if err != nil {
+		t.Fatalf("startOffsets() error = %v", err)
+	}
+
+	want := map[int]int64{0: 2, 1: 0}
+	if !reflect.DeepEqual(offsets, want) {
+		t.Errorf("startOffsets() = %v, want %v", offsets, want)
+	}
+}
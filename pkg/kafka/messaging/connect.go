@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	"github.com/aerogear/charmil-host-example/pkg/kafka"
+	"github.com/aerogear/charmil-host-example/pkg/serviceaccount/credentials"
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// NewTokenSource returns a token source for the service account of a credentials file,
+// or for the current user when no file is given
+func NewTokenSource(cfg *config.Config, credentialsFile string) (oauth2.TokenSource, error) {
+	if credentialsFile == "" {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cfg.MasAccessToken}), nil
+	}
+
+	creds, err := credentials.Read(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return ServiceAccountTokenSource(cfg.MasAuthURL, creds, cfg.Insecure), nil
+}
+
+// ServiceAccountTokenSource returns a token source which gets access tokens for a service account
+// from the token endpoint of the authentication realm
+func ServiceAccountTokenSource(authURL string, creds *credentials.Credentials, insecure bool) oauth2.TokenSource {
+	cfg := &clientcredentials.Config{
+		ClientID:     creds.ClientID,
+		ClientSecret: creds.ClientSecret,
+		TokenURL:     authURL + "/protocol/openid-connect/token",
+	}
+
+	ctx := context.Background()
+	if insecure {
+		// #nosec G402
+		transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	}
+
+	return cfg.TokenSource(ctx)
+}
+
+// ConfigForKafka returns the config to connect to the bootstrap server of a Kafka instance.
+// A local instance is connected to without TLS.
+func ConfigForKafka(kafkaInstance *kafkamgmtclient.KafkaRequest, tokens oauth2.TokenSource, insecure bool) (Config, error) {
+	bootstrap := kafka.TransformKafkaRequest(kafkaInstance).GetBootstrapServerHost()
+	if bootstrap == "" {
+		return Config{}, fmt.Errorf(`bootstrap URL is missing for Kafka instance "%v"`, kafkaInstance.GetName())
+	}
+
+	cfg := Config{
+		Bootstrap:   bootstrap,
+		TokenSource: tokens,
+	}
+
+	if host, _, err := net.SplitHostPort(bootstrap); err != nil || host != "localhost" {
+		// #nosec G402
+		cfg.TLS = &tls.Config{InsecureSkipVerify: insecure}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,457 @@
+package messaging
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go/protocol"
+	"github.com/segmentio/kafka-go/protocol/apiversions"
+	"github.com/segmentio/kafka-go/protocol/fetch"
+	"github.com/segmentio/kafka-go/protocol/findcoordinator"
+	"github.com/segmentio/kafka-go/protocol/listoffsets"
+	"github.com/segmentio/kafka-go/protocol/metadata"
+	"github.com/segmentio/kafka-go/protocol/offsetfetch"
+	"github.com/segmentio/kafka-go/protocol/produce"
+	"github.com/segmentio/kafka-go/protocol/saslauthenticate"
+	"github.com/segmentio/kafka-go/protocol/saslhandshake"
+)
+
+const (
+	errUnknownTopicOrPartition        = 3
+	errIllegalSASLState               = 34
+	errSASLAuthenticationFailed       = 58
+	errUnsupportedSASLMechanism       = 33
+	fakeBrokerNodeID            int32 = 1
+)
+
+// fakeBroker is an in-process Kafka broker with a single node which stores the records of its topics in memory.
+// It answers the requests used by the client and rejects connections which do not authenticate with its token.
+type fakeBroker struct {
+	t        *testing.T
+	listener net.Listener
+	token    string
+
+	mu     sync.Mutex
+	topics map[string][][]fakeRecord
+	// groups holds the offsets committed by each consumer group by topic and partition
+	groups map[string]map[string]map[int]int64
+	conns  map[net.Conn]struct{}
+	wg     sync.WaitGroup
+}
+
+// fakeRecord is a record stored by the broker
+type fakeRecord struct {
+	offset  int64
+	time    time.Time
+	key     []byte
+	value   []byte
+	headers []protocol.Header
+}
+
+func newFakeRecord(r *protocol.Record, offset int64) (fakeRecord, error) {
+	record := fakeRecord{offset: offset, time: r.Time, headers: r.Headers}
+	if record.time.IsZero() {
+		record.time = time.Now()
+	}
+
+	var err error
+	if record.key, err = readBytes(r.Key); err != nil {
+		return record, err
+	}
+	record.value, err = readBytes(r.Value)
+	return record, err
+}
+
+func (r fakeRecord) toProtocol() protocol.Record {
+	return protocol.Record{
+		Offset:  r.offset,
+		Time:    r.time,
+		Key:     protocol.NewBytes(r.key),
+		Value:   protocol.NewBytes(r.value),
+		Headers: r.headers,
+	}
+}
+
+// newFakeBroker starts a broker with topics of the given number of partitions
+func newFakeBroker(t *testing.T, token string, topics map[string]int) *fakeBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := &fakeBroker{
+		t:        t,
+		listener: listener,
+		token:    token,
+		topics:   map[string][][]fakeRecord{},
+		groups:   map[string]map[string]map[int]int64{},
+		conns:    map[net.Conn]struct{}{},
+	}
+	for name, partitions := range topics {
+		b.topics[name] = make([][]fakeRecord, partitions)
+	}
+
+	b.wg.Add(1)
+	go b.serve()
+	t.Cleanup(b.close)
+
+	return b
+}
+
+func (b *fakeBroker) addr() string {
+	return b.listener.Addr().String()
+}
+
+// records returns a copy of the records stored in a partition
+func (b *fakeBroker) records(topic string, partition int) []fakeRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]fakeRecord{}, b.topics[topic][partition]...)
+}
+
+// commit stores the offset committed by a consumer group for a partition
+func (b *fakeBroker) commit(groupID, topic string, partition int, offset int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.groups[groupID] == nil {
+		b.groups[groupID] = map[string]map[int]int64{}
+	}
+	if b.groups[groupID][topic] == nil {
+		b.groups[groupID][topic] = map[int]int64{}
+	}
+	b.groups[groupID][topic][partition] = offset
+}
+
+// close stops the broker and waits until all connections are closed
+func (b *fakeBroker) close() {
+	b.listener.Close()
+
+	b.mu.Lock()
+	for conn := range b.conns {
+		conn.Close()
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *fakeBroker) serve() {
+	defer b.wg.Done()
+
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		b.mu.Lock()
+		b.conns[conn] = struct{}{}
+		b.mu.Unlock()
+
+		b.wg.Add(1)
+		go b.handle(conn)
+	}
+}
+
+func (b *fakeBroker) handle(conn net.Conn) {
+	defer b.wg.Done()
+	defer func() {
+		b.mu.Lock()
+		delete(b.conns, conn)
+		b.mu.Unlock()
+		conn.Close()
+	}()
+
+	authenticated := b.token == ""
+	for {
+		version, correlationID, _, req, err := protocol.ReadRequest(conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, net.ErrClosed) {
+				b.t.Logf("fake broker: could not read request: %v", err)
+			}
+			return
+		}
+
+		var res protocol.Message
+		switch req := req.(type) {
+		case *apiversions.Request:
+			res = b.apiVersions()
+		case *saslhandshake.Request:
+			res = b.saslHandshake(req)
+		case *saslauthenticate.Request:
+			res = b.saslAuthenticate(req, &authenticated)
+		default:
+			if !authenticated {
+				b.t.Logf("fake broker: %T sent before authenticating", req)
+				return
+			}
+			res = b.serveRequest(req)
+		}
+		if res == nil {
+			b.t.Logf("fake broker: unsupported request %T", req)
+			return
+		}
+
+		if err := protocol.WriteResponse(conn, version, correlationID, res); err != nil {
+			b.t.Logf("fake broker: could not write response: %v", err)
+			return
+		}
+	}
+}
+
+func (b *fakeBroker) serveRequest(req protocol.Message) protocol.Message {
+	switch req := req.(type) {
+	case *metadata.Request:
+		return b.metadata(req)
+	case *produce.Request:
+		return b.produce(req)
+	case *fetch.Request:
+		return b.fetch(req)
+	case *listoffsets.Request:
+		return b.listOffsets(req)
+	case *findcoordinator.Request:
+		return b.findCoordinator()
+	case *offsetfetch.Request:
+		return b.offsetFetch(req)
+	}
+	return nil
+}
+
+func (b *fakeBroker) apiVersions() protocol.Message {
+	keys := []protocol.ApiKey{
+		protocol.ApiVersions,
+		protocol.SaslHandshake,
+		protocol.SaslAuthenticate,
+		protocol.Metadata,
+		protocol.Produce,
+		protocol.Fetch,
+		protocol.ListOffsets,
+		protocol.FindCoordinator,
+		protocol.OffsetFetch,
+	}
+
+	res := &apiversions.Response{}
+	for _, k := range keys {
+		res.ApiKeys = append(res.ApiKeys, apiversions.ApiKeyResponse{
+			ApiKey:     int16(k),
+			MinVersion: k.MinVersion(),
+			MaxVersion: k.MaxVersion(),
+		})
+	}
+	return res
+}
+
+func (b *fakeBroker) saslHandshake(req *saslhandshake.Request) protocol.Message {
+	res := &saslhandshake.Response{Mechanisms: []string{OAuthBearerMechanism}}
+	if req.Mechanism != OAuthBearerMechanism {
+		res.ErrorCode = errUnsupportedSASLMechanism
+	}
+	return res
+}
+
+func (b *fakeBroker) saslAuthenticate(req *saslauthenticate.Request, authenticated *bool) protocol.Message {
+	if *authenticated {
+		return &saslauthenticate.Response{ErrorCode: errIllegalSASLState}
+	}
+	if !bytes.Equal(req.AuthBytes, oauthBearerInitialResponse(b.token)) {
+		return &saslauthenticate.Response{ErrorCode: errSASLAuthenticationFailed, ErrorMessage: "invalid token"}
+	}
+	*authenticated = true
+	return &saslauthenticate.Response{}
+}
+
+func (b *fakeBroker) metadata(req *metadata.Request) protocol.Message {
+	host, port, _ := net.SplitHostPort(b.addr())
+	portNumber, _ := strconv.Atoi(port)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	names := req.TopicNames
+	if names == nil {
+		for name := range b.topics {
+			names = append(names, name)
+		}
+	}
+
+	res := &metadata.Response{
+		Brokers:      []metadata.ResponseBroker{{NodeID: fakeBrokerNodeID, Host: host, Port: int32(portNumber)}},
+		ControllerID: fakeBrokerNodeID,
+	}
+	for _, name := range names {
+		partitions, ok := b.topics[name]
+		if !ok {
+			res.Topics = append(res.Topics, metadata.ResponseTopic{Name: name, ErrorCode: errUnknownTopicOrPartition})
+			continue
+		}
+
+		topic := metadata.ResponseTopic{Name: name}
+		for i := range partitions {
+			topic.Partitions = append(topic.Partitions, metadata.ResponsePartition{
+				PartitionIndex: int32(i),
+				LeaderID:       fakeBrokerNodeID,
+				ReplicaNodes:   []int32{fakeBrokerNodeID},
+				IsrNodes:       []int32{fakeBrokerNodeID},
+			})
+		}
+		res.Topics = append(res.Topics, topic)
+	}
+	return res
+}
+
+func (b *fakeBroker) produce(req *produce.Request) protocol.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &produce.Response{}
+	for _, t := range req.Topics {
+		topic := produce.ResponseTopic{Topic: t.Topic}
+		for _, p := range t.Partitions {
+			partition := produce.ResponsePartition{Partition: p.Partition}
+			log, ok := b.partition(t.Topic, p.Partition)
+			if !ok {
+				partition.ErrorCode = errUnknownTopicOrPartition
+				topic.Partitions = append(topic.Partitions, partition)
+				continue
+			}
+
+			partition.BaseOffset = int64(len(*log))
+			for {
+				r, err := p.RecordSet.Records.ReadRecord()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					b.t.Errorf("fake broker: could not read produced record: %v", err)
+					break
+				}
+				record, err := newFakeRecord(r, int64(len(*log)))
+				if err != nil {
+					b.t.Errorf("fake broker: could not read produced record: %v", err)
+					break
+				}
+				*log = append(*log, record)
+			}
+			topic.Partitions = append(topic.Partitions, partition)
+		}
+		res.Topics = append(res.Topics, topic)
+	}
+	return res
+}
+
+func (b *fakeBroker) fetch(req *fetch.Request) protocol.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &fetch.Response{}
+	for _, t := range req.Topics {
+		topic := fetch.ResponseTopic{Topic: t.Topic}
+		for _, p := range t.Partitions {
+			partition := fetch.ResponsePartition{Partition: p.Partition}
+			log, ok := b.partition(t.Topic, p.Partition)
+			if !ok {
+				partition.ErrorCode = errUnknownTopicOrPartition
+				topic.Partitions = append(topic.Partitions, partition)
+				continue
+			}
+
+			partition.HighWatermark = int64(len(*log))
+			partition.LastStableOffset = partition.HighWatermark
+
+			// the record batch is encoded with a base offset of 0, so the whole log is returned
+			// and the client skips the records before the fetch offset, as it does with real batches
+			var records []protocol.Record
+			if p.FetchOffset < int64(len(*log)) {
+				for _, r := range *log {
+					records = append(records, r.toProtocol())
+				}
+			}
+			partition.RecordSet = protocol.RecordSet{Version: 2, Records: protocol.NewRecordReader(records...)}
+			if len(records) == 0 {
+				// version 2 record sets cannot be encoded without records, while version 1 ones are written as empty
+				partition.RecordSet.Version = 1
+			}
+			topic.Partitions = append(topic.Partitions, partition)
+		}
+		res.Topics = append(res.Topics, topic)
+	}
+	return res
+}
+
+func (b *fakeBroker) listOffsets(req *listoffsets.Request) protocol.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	res := &listoffsets.Response{}
+	for _, t := range req.Topics {
+		topic := listoffsets.ResponseTopic{Topic: t.Topic}
+		for _, p := range t.Partitions {
+			partition := listoffsets.ResponsePartition{Partition: p.Partition, Timestamp: p.Timestamp}
+			log, ok := b.partition(t.Topic, p.Partition)
+			switch {
+			case !ok:
+				partition.ErrorCode = errUnknownTopicOrPartition
+			case p.Timestamp == FirstOffset:
+				partition.Offset = 0
+			case p.Timestamp == LastOffset:
+				partition.Offset = int64(len(*log))
+			default:
+				// the first record at or after the timestamp, or -1 when there is none
+				partition.Offset, partition.Timestamp = -1, -1
+				for _, r := range *log {
+					if ms := r.time.UnixNano() / int64(time.Millisecond); ms >= p.Timestamp {
+						partition.Offset, partition.Timestamp = r.offset, ms
+						break
+					}
+				}
+			}
+			topic.Partitions = append(topic.Partitions, partition)
+		}
+		res.Topics = append(res.Topics, topic)
+	}
+	return res
+}
+
+func (b *fakeBroker) findCoordinator() protocol.Message {
+	host, port, _ := net.SplitHostPort(b.addr())
+	portNumber, _ := strconv.Atoi(port)
+
+	return &findcoordinator.Response{NodeID: fakeBrokerNodeID, Host: host, Port: int32(portNumber)}
+}
+
+func (b *fakeBroker) offsetFetch(req *offsetfetch.Request) protocol.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	committed := b.groups[req.GroupID]
+
+	res := &offsetfetch.Response{}
+	for _, t := range req.Topics {
+		topic := offsetfetch.ResponseTopic{Name: t.Name}
+		for _, p := range t.PartitionIndexes {
+			offset, ok := committed[t.Name][int(p)]
+			if !ok {
+				offset = -1
+			}
+			topic.Partitions = append(topic.Partitions, offsetfetch.ResponsePartition{PartitionIndex: p, CommittedOffset: offset})
+		}
+		res.Topics = append(res.Topics, topic)
+	}
+	return res
+}
+
+// partition returns the records of a partition, the caller must hold the lock
+func (b *fakeBroker) partition(topic string, partition int32) (*[]fakeRecord, bool) {
+	partitions, ok := b.topics[topic]
+	if !ok || partition < 0 || int(partition) >= len(partitions) {
+		return nil, false
+	}
+	return &partitions[partition], true
+}
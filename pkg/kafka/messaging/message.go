@@ -0,0 +1,267 @@
+package messaging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Formats of the messages read by produce and written by consume
+const (
+	// FormatRaw is one message value per line, optionally preceded by the key and a separator
+	FormatRaw = "raw"
+	// FormatJSON is one JSON object per message with the key, value and headers
+	FormatJSON = "json"
+)
+
+// ValidFormats are the supported formats of messages
+var ValidFormats = []string{FormatRaw, FormatJSON}
+
+// Special offsets to start consuming from
+const (
+	OffsetEarliest = "earliest"
+	OffsetLatest   = "latest"
+
+	FirstOffset = kafka.FirstOffset
+	LastOffset  = kafka.LastOffset
+)
+
+// AnyPartition lets the producer choose the partition of a message
+const AnyPartition = -1
+
+// Message is a record of a Kafka topic
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Time      time.Time
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+}
+
+// Header is a header of a message
+type Header struct {
+	Key   string
+	Value string
+}
+
+// jsonMessage is the representation of a message in the JSON format
+type jsonMessage struct {
+	Topic     string            `json:"topic,omitempty"`
+	Partition *int              `json:"partition,omitempty"`
+	Offset    *int64            `json:"offset,omitempty"`
+	Timestamp *time.Time        `json:"timestamp,omitempty"`
+	Key       *string           `json:"key"`
+	Value     json.RawMessage   `json:"value"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// InputOptions defines how messages are read
+type InputOptions struct {
+	Format string
+	// Key is the key of messages which do not have one
+	Key string
+	// KeySeparator splits each raw line into a key and a value
+	KeySeparator string
+	// Headers are added to every message
+	Headers []Header
+}
+
+// OutputOptions defines how messages are written
+type OutputOptions struct {
+	Format string
+	// KeySeparator prints the key of raw messages before the value when set
+	KeySeparator string
+}
+
+// ParseOffset parses the offset to start consuming from,
+// which is "earliest", "latest" or a non-negative number
+func ParseOffset(value string) (int64, error) {
+	switch strings.ToLower(value) {
+	case OffsetEarliest:
+		return FirstOffset, nil
+	case OffsetLatest:
+		return LastOffset, nil
+	}
+
+	offset, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid offset %q", value)
+	}
+	return offset, nil
+}
+
+// ParseHeader parses a header in the form "key=value"
+func ParseHeader(value string) (Header, error) {
+	i := strings.Index(value, "=")
+	if i <= 0 {
+		return Header{}, fmt.Errorf("invalid header %q", value)
+	}
+	return Header{Key: value[:i], Value: value[i+1:]}, nil
+}
+
+// ReadMessages reads all messages from the reader in the format of the options
+func ReadMessages(r io.Reader, opts InputOptions) ([]Message, error) {
+	var (
+		messages []Message
+		err      error
+	)
+
+	switch opts.Format {
+	case FormatJSON:
+		messages, err = readJSONMessages(r)
+	case FormatRaw, "":
+		messages, err = readRawMessages(r, opts.KeySeparator)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range messages {
+		if messages[i].Key == nil && opts.Key != "" {
+			messages[i].Key = []byte(opts.Key)
+		}
+		messages[i].Headers = append(append([]Header{}, opts.Headers...), messages[i].Headers...)
+	}
+
+	return messages, nil
+}
+
+// readRawMessages reads a message from every non-empty line
+func readRawMessages(r io.Reader, keySeparator string) ([]Message, error) {
+	messages := []Message{}
+	reader := bufio.NewReader(r)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			msg := Message{Partition: AnyPartition, Value: []byte(line)}
+			if keySeparator != "" {
+				if i := strings.Index(line, keySeparator); i >= 0 {
+					msg.Key = []byte(line[:i])
+					msg.Value = []byte(line[i+len(keySeparator):])
+				}
+			}
+			messages = append(messages, msg)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		}
+	}
+}
+
+// readJSONMessages reads a stream of JSON objects.
+// A value which is not a JSON string is sent as compact JSON.
+func readJSONMessages(r io.Reader) ([]Message, error) {
+	messages := []Message{}
+	decoder := json.NewDecoder(r)
+
+	for {
+		var m jsonMessage
+		err := decoder.Decode(&m)
+		if errors.Is(err, io.EOF) {
+			return messages, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		msg := Message{Partition: AnyPartition}
+		if m.Partition != nil {
+			msg.Partition = *m.Partition
+		}
+		if m.Key != nil {
+			msg.Key = []byte(*m.Key)
+		}
+
+		var value string
+		if err = json.Unmarshal(m.Value, &value); err == nil {
+			msg.Value = []byte(value)
+		} else if len(m.Value) > 0 && string(m.Value) != "null" {
+			var compact bytes.Buffer
+			if err = json.Compact(&compact, m.Value); err != nil {
+				return nil, err
+			}
+			msg.Value = compact.Bytes()
+		}
+
+		msg.Headers = sortedHeaders(m.Headers)
+
+		messages = append(messages, msg)
+	}
+}
+
+// WriteMessage writes a message in the format of the options
+func WriteMessage(w io.Writer, msg Message, opts OutputOptions) error {
+	if opts.Format != FormatJSON {
+		var line []byte
+		if opts.KeySeparator != "" {
+			line = append(append(line, msg.Key...), opts.KeySeparator...)
+		}
+		line = append(append(line, msg.Value...), '\n')
+		_, err := w.Write(line)
+		return err
+	}
+
+	value, err := json.Marshal(string(msg.Value))
+	if err != nil {
+		return err
+	}
+
+	m := jsonMessage{
+		Topic:     msg.Topic,
+		Partition: &msg.Partition,
+		Offset:    &msg.Offset,
+		Value:     value,
+	}
+	if !msg.Time.IsZero() {
+		m.Timestamp = &msg.Time
+	}
+	if msg.Key != nil {
+		key := string(msg.Key)
+		m.Key = &key
+	}
+	if len(msg.Headers) > 0 {
+		m.Headers = make(map[string]string, len(msg.Headers))
+		for _, h := range msg.Headers {
+			m.Headers[h.Key] = h.Value
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// sortedHeaders converts a map of headers to a list ordered by key
+func sortedHeaders(headers map[string]string) []Header {
+	list := make([]Header, 0, len(headers))
+	for key, value := range headers {
+		list = append(list, Header{Key: key, Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Key < list[j].Key
+	})
+	return list
+}
@@ -0,0 +1,130 @@
+package messaging
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		opts    InputOptions
+		want    []Message
+		wantErr bool
+	}{
+		{
+			name:  "raw lines",
+			input: "first\n\nsecond\r\n",
+			opts:  InputOptions{Format: FormatRaw},
+			want: []Message{
+				{Partition: AnyPartition, Value: []byte("first"), Headers: []Header{}},
+				{Partition: AnyPartition, Value: []byte("second"), Headers: []Header{}},
+			},
+		},
+		{
+			name:  "raw lines with keys and headers",
+			input: "k1:v:1\nno key",
+			opts:  InputOptions{Format: FormatRaw, KeySeparator: ":", Key: "default", Headers: []Header{{Key: "h", Value: "1"}}},
+			want: []Message{
+				{Partition: AnyPartition, Key: []byte("k1"), Value: []byte("v:1"), Headers: []Header{{Key: "h", Value: "1"}}},
+				{Partition: AnyPartition, Key: []byte("default"), Value: []byte("no key"), Headers: []Header{{Key: "h", Value: "1"}}},
+			},
+		},
+		{
+			name: "json",
+			input: `{"key":"k1","value":"v1","headers":{"b":"2","a":"1"}}
+{"partition":2,"value":{"id": 1}}`,
+			opts: InputOptions{Format: FormatJSON},
+			want: []Message{
+				{Partition: AnyPartition, Key: []byte("k1"), Value: []byte("v1"), Headers: []Header{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}},
+				{Partition: 2, Value: []byte(`{"id":1}`), Headers: []Header{}},
+			},
+		},
+		{
+			name:    "invalid json",
+			input:   `{"key":`,
+			opts:    InputOptions{Format: FormatJSON},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadMessages(strings.NewReader(tt.input), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadMessages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadMessages() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteMessage(t *testing.T) {
+	msg := Message{
+		Topic:     "orders",
+		Partition: 1,
+		Offset:    42,
+		Time:      time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC),
+		Key:       []byte("k1"),
+		Value:     []byte(`{"id":1}`),
+		Headers:   []Header{{Key: "source", Value: "test"}},
+	}
+
+	tests := []struct {
+		name string
+		opts OutputOptions
+		want string
+	}{
+		{name: "raw", opts: OutputOptions{Format: FormatRaw}, want: "{\"id\":1}\n"},
+		{name: "raw with key", opts: OutputOptions{Format: FormatRaw, KeySeparator: "\t"}, want: "k1\t{\"id\":1}\n"},
+		{
+			name: "json",
+			opts: OutputOptions{Format: FormatJSON},
+			want: `{"topic":"orders","partition":1,"offset":42,"timestamp":"2021-09-01T12:00:00Z","key":"k1","value":"{\"id\":1}","headers":{"source":"test"}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := WriteMessage(&out, msg, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("WriteMessage() = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "earliest", want: FirstOffset},
+		{value: "Latest", want: LastOffset},
+		{value: "100", want: 100},
+		{value: "-5", wantErr: true},
+		{value: "first", wantErr: true},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseOffset(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOffset() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOffset() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go/sasl"
+	"golang.org/x/oauth2"
+)
+
+// OAuthBearerMechanism is the name of the SASL mechanism used to authenticate with an access token
+const OAuthBearerMechanism = "OAUTHBEARER"
+
+// oauthBearer implements the SASL/OAUTHBEARER mechanism (RFC 7628),
+// sending an access token from the token source when a connection is opened
+type oauthBearer struct {
+	tokens oauth2.TokenSource
+}
+
+func (m *oauthBearer) Name() string {
+	return OAuthBearerMechanism
+}
+
+func (m *oauthBearer) Start(ctx context.Context) (sasl.StateMachine, []byte, error) {
+	token, err := m.tokens.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, oauthBearerInitialResponse(token.AccessToken), nil
+}
+
+// Next is called when the broker sends a challenge, which only happens when the token was rejected.
+// The exchange is ended so that the broker reports the authentication error.
+func (m *oauthBearer) Next(ctx context.Context, challenge []byte) (bool, []byte, error) {
+	return true, nil, nil
+}
+
+// oauthBearerInitialResponse returns the client first message of the OAUTHBEARER mechanism
+func oauthBearerInitialResponse(accessToken string) []byte {
+	return []byte("n,,\x01auth=Bearer " + accessToken + "\x01\x01")
+}
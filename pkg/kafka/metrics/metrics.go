@@ -0,0 +1,216 @@
+// Package metrics contains functions for reading and rendering the metrics of a Kafka instance
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// NameLabel is the label holding the name of the metric of a series
+const NameLabel = "__name__"
+
+// KnownMetrics are the metrics exposed for a Kafka instance
+var KnownMetrics = []string{
+	"kafka_server_brokertopicmetrics_bytes_in_total",
+	"kafka_server_brokertopicmetrics_bytes_out_total",
+	"kafka_server_brokertopicmetrics_messages_in_total",
+	"kafka_broker_quota_softlimitbytes",
+	"kafka_broker_quota_totalstorageusedbytes",
+	"kubelet_volume_stats_available_bytes",
+	"kubelet_volume_stats_used_bytes",
+	"kafka_topic:kafka_log_log_size:sum",
+	"kafka_topic:kafka_topic_partitions:sum",
+	"kafka_topic:kafka_topic_partitions:count",
+	"kafka_controller_kafkacontroller_global_partition_count",
+	"kafka_controller_kafkacontroller_offline_partitions_count",
+	"kafka_cluster_partition_underreplicated",
+	"kafka_namespace:kafka_server_socket_server_metrics_connection_count:sum",
+	"kafka_namespace:kafka_server_socket_server_metrics_connection_creation_rate:sum",
+	"kafka_namespace:haproxy_server_bytes_in_total:rate5m",
+	"kafka_namespace:haproxy_server_bytes_out_total:rate5m",
+	"consumergroup:kafka_consumergroup_members:count",
+}
+
+// sparkRunes are the bars used to draw a sparkline, from lowest to highest
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// Point is a single value of a series
+type Point struct {
+	// Timestamp in milliseconds since the epoch
+	Timestamp int64
+	Value     float64
+}
+
+// Series is a metric and its values for a single set of labels
+type Series struct {
+	Name   string
+	Labels map[string]string
+	Points []Point
+}
+
+// FromRangeQuery converts the items of a range query into series
+func FromRangeQuery(items []kafkamgmtclient.RangeQuery) []Series {
+	series := make([]Series, 0, len(items))
+	for _, item := range items {
+		s := newSeries(item.GetMetric())
+		for _, v := range item.GetValues() {
+			s.Points = append(s.Points, Point{Timestamp: v.GetTimestamp(), Value: v.GetValue()})
+		}
+		series = append(series, s)
+	}
+
+	sortSeries(series)
+
+	return series
+}
+
+// FromInstantQuery converts the items of an instant query into series with a single point each
+func FromInstantQuery(items []kafkamgmtclient.InstantQuery) []Series {
+	series := make([]Series, 0, len(items))
+	for _, item := range items {
+		s := newSeries(item.GetMetric())
+		s.Points = []Point{{Timestamp: item.GetTimestamp(), Value: item.GetValue()}}
+		series = append(series, s)
+	}
+
+	sortSeries(series)
+
+	return series
+}
+
+// LabelString returns the labels of the series, other than its name, as sorted "key=value" pairs
+func (s *Series) LabelString() string {
+	keys := make([]string, 0, len(s.Labels))
+	for k := range s.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", k, s.Labels[k]))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// Values returns the values of all points of the series
+func (s *Series) Values() []float64 {
+	values := make([]float64, len(s.Points))
+	for i, p := range s.Points {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// Latest returns the value of the last point of the series
+func (s *Series) Latest() float64 {
+	if len(s.Points) == 0 {
+		return 0
+	}
+	return s.Points[len(s.Points)-1].Value
+}
+
+// MinMax returns the lowest and highest finite values of the series
+func (s *Series) MinMax() (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, p := range s.Points {
+		if !isFinite(p.Value) {
+			continue
+		}
+		min = math.Min(min, p.Value)
+		max = math.Max(max, p.Value)
+	}
+
+	if min > max {
+		return 0, 0
+	}
+
+	return min, max
+}
+
+// Sparkline draws the values as a line of bars scaled between the lowest and highest value.
+// When there are more values than width, consecutive values are averaged into a single bar.
+// Non-finite values cannot be scaled and are drawn as gaps.
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width < 1 {
+		return ""
+	}
+
+	values = downsample(values, width)
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if isFinite(v) {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if !isFinite(v) {
+			b.WriteRune(' ')
+			continue
+		}
+
+		i := 0
+		if max > min {
+			i = int((v - min) / (max - min) * float64(len(sparkRunes)-1))
+		}
+		b.WriteRune(sparkRunes[i])
+	}
+
+	return b.String()
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// downsample averages consecutive values so that at most width values are returned
+func downsample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+
+	sampled := make([]float64, width)
+	for i := range sampled {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		sampled[i] = sum / float64(end-start)
+	}
+
+	return sampled
+}
+
+func newSeries(metric map[string]string) Series {
+	s := Series{
+		Name:   metric[NameLabel],
+		Labels: map[string]string{},
+	}
+	for k, v := range metric {
+		if k != NameLabel {
+			s.Labels[k] = v
+		}
+	}
+	return s
+}
+
+func sortSeries(series []Series) {
+	sort.SliceStable(series, func(i, j int) bool {
+		if series[i].Name != series[j].Name {
+			return series[i].Name < series[j].Name
+		}
+		return series[i].LabelString() < series[j].LabelString()
+	})
+}
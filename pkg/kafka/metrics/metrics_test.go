@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{
+			name:   "Should scale values between the lowest and highest bar",
+			values: []float64{0, 1, 2, 3, 4, 5, 6, 7},
+			width:  10,
+			want:   "▁▂▃▄▅▆▇█",
+		},
+		{
+			name:   "Should draw the lowest bar for constant values",
+			values: []float64{3, 3, 3},
+			width:  10,
+			want:   "▁▁▁",
+		},
+		{
+			name:   "Should average values to fit the width",
+			values: []float64{0, 0, 7, 7},
+			width:  2,
+			want:   "▁█",
+		},
+		{
+			name:   "Should draw non-finite values as gaps",
+			values: []float64{0, math.Inf(1), 7, math.NaN(), math.Inf(-1)},
+			width:  10,
+			want:   "▁ █  ",
+		},
+		{
+			name:   "Should draw only gaps when no value is finite",
+			values: []float64{math.Inf(1), math.NaN()},
+			width:  10,
+			want:   "  ",
+		},
+		{
+			name:   "Should return an empty string when there are no values",
+			values: []float64{},
+			width:  10,
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sparkline(tt.values, tt.width); got != tt.want {
+				t.Errorf("Sparkline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromRangeQuery(t *testing.T) {
+	item := func(name string, topic string, values ...float64) kafkamgmtclient.RangeQuery {
+		q := kafkamgmtclient.RangeQuery{}
+		q.SetMetric(map[string]string{NameLabel: name, "topic": topic})
+		points := []kafkamgmtclient.Values{}
+		for i, v := range values {
+			p := kafkamgmtclient.Values{Value: v}
+			p.SetTimestamp(int64(i) * 1000)
+			points = append(points, p)
+		}
+		q.SetValues(points)
+		return q
+	}
+
+	series := FromRangeQuery([]kafkamgmtclient.RangeQuery{
+		item("b_metric", "orders", 1, 2),
+		item("a_metric", "payments", 5, 1, 3),
+		item("a_metric", "orders", 4),
+	})
+
+	if len(series) != 3 {
+		t.Fatalf("FromRangeQuery() returned %v series, want 3", len(series))
+	}
+	if series[0].Name != "a_metric" || series[0].LabelString() != "topic=orders" {
+		t.Errorf("FromRangeQuery() first series = %v %v, want a_metric topic=orders", series[0].Name, series[0].LabelString())
+	}
+
+	s := series[1]
+	if min, max := s.MinMax(); min != 1 || max != 5 {
+		t.Errorf("MinMax() = %v, %v, want 1, 5", min, max)
+	}
+	if s.Latest() != 3 {
+		t.Errorf("Latest() = %v, want 3", s.Latest())
+	}
+}
+
+func TestSeriesMinMax(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		wantMin float64
+		wantMax float64
+	}{
+		{
+			name:    "Should return zero without points",
+			values:  []float64{},
+			wantMin: 0,
+			wantMax: 0,
+		},
+		{
+			name:    "Should skip non-finite values",
+			values:  []float64{4, math.NaN(), 2, math.Inf(1), 7, math.Inf(-1)},
+			wantMin: 2,
+			wantMax: 7,
+		},
+		{
+			name:    "Should return zero when no value is finite",
+			values:  []float64{math.NaN(), math.Inf(1)},
+			wantMin: 0,
+			wantMax: 0,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{}
+			for _, v := range tt.values {
+				s.Points = append(s.Points, Point{Value: v})
+			}
+			if min, max := s.MinMax(); min != tt.wantMin || max != tt.wantMax {
+				t.Errorf("MinMax() = %v, %v, want %v, %v", min, max, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Spec is a declarative definition of a Kafka instance
+type Spec struct {
+	Name     string `json:"name" yaml:"name"`
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Region   string `json:"region,omitempty" yaml:"region,omitempty"`
+	MultiAZ  *bool  `json:"multi_az,omitempty" yaml:"multi_az,omitempty"`
+}
+
+// ReadSpecs reads all Kafka instance definitions from a YAML or JSON stream.
+// A YAML stream can contain several documents separated by "---", empty documents are ignored.
+func ReadSpecs(r io.Reader) ([]Spec, error) {
+	specs := []Spec{}
+
+	decoder := yaml.NewDecoder(r)
+	decoder.SetStrict(true)
+	for {
+		var spec Spec
+		err := decoder.Decode(&spec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if spec == (Spec{}) {
+			continue
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
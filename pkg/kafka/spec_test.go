@@ -0,0 +1,74 @@
+package kafka
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSpecs(t *testing.T) {
+	multiAZ := false
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []Spec
+		wantErr bool
+	}{
+		{
+			name: "Should read a single YAML document",
+			input: `
+name: my-kafka
+provider: aws
+region: us-east-1
+multi_az: false
+`,
+			want: []Spec{
+				{Name: "my-kafka", Provider: "aws", Region: "us-east-1", MultiAZ: &multiAZ},
+			},
+		},
+		{
+			name: "Should read multiple YAML documents and ignore empty ones",
+			input: `
+name: kafka-one
+---
+---
+name: kafka-two
+region: eu-west-1
+`,
+			want: []Spec{
+				{Name: "kafka-one"},
+				{Name: "kafka-two", Region: "eu-west-1"},
+			},
+		},
+		{
+			name:  "Should read a JSON document",
+			input: `{"name": "my-kafka", "provider": "aws"}`,
+			want: []Spec{
+				{Name: "my-kafka", Provider: "aws"},
+			},
+		},
+		{
+			name:    "Should fail on unknown fields",
+			input:   `nmae: my-kafka`,
+			wantErr: true,
+		},
+		{
+			name:  "Should return no specs for empty input",
+			input: ``,
+			want:  []Spec{},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadSpecs(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadSpecs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadSpecs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
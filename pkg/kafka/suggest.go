@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// maxSuggestions is the maximum number of names suggested when an instance is not found
+	maxSuggestions = 3
+	// maxSuggestionCandidates is the number of instances fetched to look for suggestions
+	maxSuggestionCandidates = 100
+)
+
+// SuggestNames returns the candidates which are similar to name, closest first.
+// A candidate is similar when it starts with name or is within a small edit distance of it.
+func SuggestNames(name string, candidates []string) []string {
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	type suggestion struct {
+		name     string
+		distance int
+	}
+
+	suggestions := []suggestion{}
+	seen := map[string]bool{}
+	for _, c := range candidates {
+		if c == name || seen[c] {
+			continue
+		}
+		seen[c] = true
+
+		distance := editDistance(strings.ToLower(name), strings.ToLower(c))
+		if distance <= maxDistance || strings.HasPrefix(strings.ToLower(c), strings.ToLower(name)) {
+			suggestions = append(suggestions, suggestion{c, distance})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+
+	names := []string{}
+	for i := 0; i < len(suggestions) && i < maxSuggestions; i++ {
+		names = append(names, suggestions[i].name)
+	}
+
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,58 @@
+package kafka
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSuggestNames(t *testing.T) {
+	candidates := []string{"my-kafka", "my-kafka-dev", "prod-kafka", "mykafka", "other"}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "Should suggest names within a small edit distance, closest first",
+			input: "my-kafak",
+			want:  []string{"my-kafka"},
+		},
+		{
+			name:  "Should suggest names starting with the input after closer names",
+			input: "my-kafka-",
+			want:  []string{"my-kafka", "mykafka", "my-kafka-dev"},
+		},
+		{
+			name:  "Should not suggest anything for unrelated names",
+			input: "registry",
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestNames(tt.input, candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"kafka", "kafka", 0},
+		{"kafka", "kafak", 2},
+		{"kafka", "kafkas", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
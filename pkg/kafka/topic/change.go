@@ -0,0 +1,73 @@
+package topic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aerogear/charmil/core/utils/localize"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// ApplyChange performs the API request for a single planned change
+func ApplyChange(ctx context.Context, api *kafkainstanceclient.APIClient, change Change) (httpRes *http.Response, err error) {
+	configEntryMap := map[string]*string{}
+	for _, cfg := range change.Config {
+		value := cfg.To
+		configEntryMap[cfg.Key] = &value
+	}
+
+	switch change.Action {
+	case ActionCreate:
+		topicInput := kafkainstanceclient.NewTopicInput{
+			Name: change.Topic,
+			Settings: kafkainstanceclient.TopicSettings{
+				NumPartitions: change.ToPartitions,
+				Config:        CreateConfigEntries(configEntryMap),
+			},
+		}
+		_, httpRes, err = api.TopicsApi.CreateTopic(ctx).NewTopicInput(topicInput).Execute()
+	case ActionUpdate:
+		topicSettings := kafkainstanceclient.UpdateTopicInput{}
+		if change.ToPartitions != 0 {
+			topicSettings.SetNumPartitions(change.ToPartitions)
+		}
+		if len(configEntryMap) > 0 {
+			topicSettings.SetConfig(*CreateConfigEntries(configEntryMap))
+		}
+		_, httpRes, err = api.TopicsApi.UpdateTopic(ctx, change.Topic).UpdateTopicInput(topicSettings).Execute()
+	case ActionDelete:
+		httpRes, err = api.TopicsApi.DeleteTopic(ctx, change.Topic).Execute()
+	}
+
+	return httpRes, err
+}
+
+// APIError maps the HTTP status code of a failed topic request to a localized error.
+// topicName is empty for requests which are not about a single topic.
+func APIError(localizer localize.Localizer, httpRes *http.Response, err error, topicName string, instanceName string, operation string) error {
+	if httpRes == nil {
+		return err
+	}
+
+	operationTmplPair := localize.NewEntry("Operation", operation)
+	switch httpRes.StatusCode {
+	case 401:
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.error.unauthorized", operationTmplPair))
+	case 403:
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.error.forbidden", operationTmplPair))
+	case 404:
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.error.notFoundError", localize.NewEntry("TopicName", topicName), localize.NewEntry("InstanceName", instanceName)))
+	case 409:
+		return errors.New(localizer.LocalizeByID("kafka.topic.create.error.conflictError", localize.NewEntry("TopicName", topicName), localize.NewEntry("InstanceName", instanceName)))
+	case 500:
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.error.internalServerError"))
+	case 503:
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.error.unableToConnectToKafka", localize.NewEntry("Name", instanceName)))
+	default:
+		if topicName == "" {
+			return err
+		}
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.error.operationFailed", operationTmplPair, localize.NewEntry("TopicName", topicName), localize.NewEntry("Error", err)))
+	}
+}
@@ -0,0 +1,291 @@
+package topic
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/kafka"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigType is the type of the value of a topic config entry
+type ConfigType string
+
+const (
+	ConfigTypeInt     ConfigType = "int"
+	ConfigTypeLong    ConfigType = "long"
+	ConfigTypeDouble  ConfigType = "double"
+	ConfigTypeBoolean ConfigType = "boolean"
+	ConfigTypeString  ConfigType = "string"
+)
+
+// ConfigDefinition describes a known Kafka topic config entry
+type ConfigDefinition struct {
+	Key         string
+	Type        ConfigType
+	Min         *float64
+	Max         *float64
+	ValidValues []string
+	Default     string
+	Description string
+}
+
+func bound(v float64) *float64 {
+	return &v
+}
+
+// KnownConfigs is the catalogue of the Kafka topic configs which can be set
+var KnownConfigs = []ConfigDefinition{
+	{Key: CleanupPolicy, Type: ConfigTypeString, ValidValues: ValidCleanupPolicies, Default: "delete", Description: "Determines whether log messages are deleted, compacted, or both"},
+	{Key: "compression.type", Type: ConfigTypeString, ValidValues: []string{"producer", "uncompressed", "zstd", "lz4", "snappy", "gzip"}, Default: "producer", Description: "The compression type of the topic"},
+	{Key: "delete.retention.ms", Type: ConfigTypeLong, Min: bound(0), Default: "86400000", Description: "The time to retain delete tombstone markers for compacted topics"},
+	{Key: "file.delete.delay.ms", Type: ConfigTypeLong, Min: bound(0), Default: "60000", Description: "The time to wait before deleting a file from the filesystem"},
+	{Key: "flush.messages", Type: ConfigTypeLong, Min: bound(0), Default: "9223372036854775807", Description: "The number of messages written to a log partition before it is flushed to disk"},
+	{Key: "flush.ms", Type: ConfigTypeLong, Min: bound(0), Default: "9223372036854775807", Description: "The maximum time a message can stay in the log before it is flushed to disk"},
+	{Key: "index.interval.bytes", Type: ConfigTypeInt, Min: bound(0), Default: "4096", Description: "How frequently Kafka adds an index entry to its offset index"},
+	{Key: "max.compaction.lag.ms", Type: ConfigTypeLong, Min: bound(1), Default: "9223372036854775807", Description: "The maximum time a message remains ineligible for compaction"},
+	{Key: "max.message.bytes", Type: ConfigTypeInt, Min: bound(0), Default: "1048588", Description: "The largest record batch size allowed by Kafka"},
+	{Key: "message.downconversion.enable", Type: ConfigTypeBoolean, Default: "true", Description: "Whether down-conversion of message formats is enabled to satisfy consume requests"},
+	{Key: "message.timestamp.difference.max.ms", Type: ConfigTypeLong, Min: bound(0), Default: "9223372036854775807", Description: "The maximum difference allowed between the broker and the message timestamps"},
+	{Key: "message.timestamp.type", Type: ConfigTypeString, ValidValues: []string{"CreateTime", "LogAppendTime"}, Default: "CreateTime", Description: "Whether the timestamp in the message is the create time or the log append time"},
+	{Key: "min.cleanable.dirty.ratio", Type: ConfigTypeDouble, Min: bound(0), Max: bound(1), Default: "0.5", Description: "The ratio of the log that must be uncompacted before it is eligible for cleaning"},
+	{Key: "min.compaction.lag.ms", Type: ConfigTypeLong, Min: bound(0), Default: "0", Description: "The minimum time a message remains uncompacted in the log"},
+	{Key: "min.insync.replicas", Type: ConfigTypeInt, Min: bound(1), Default: "1", Description: "The minimum number of replicas that must acknowledge a write"},
+	{Key: "preallocate", Type: ConfigTypeBoolean, Default: "false", Description: "Whether to preallocate the file on disk when creating a new log segment"},
+	{Key: RetentionSizeKey, Type: ConfigTypeLong, Min: bound(-1), Default: "-1", Description: "The maximum total size of a partition log segments before old log segments are deleted"},
+	{Key: RetentionMsKey, Type: ConfigTypeLong, Min: bound(-1), Default: "604800000", Description: "The period of time in milliseconds the broker will retain a partition log"},
+	{Key: "segment.bytes", Type: ConfigTypeInt, Min: bound(14), Default: "1073741824", Description: "The segment file size for the log"},
+	{Key: "segment.index.bytes", Type: ConfigTypeInt, Min: bound(4), Default: "10485760", Description: "The size of the index that maps offsets to file positions"},
+	{Key: "segment.jitter.ms", Type: ConfigTypeLong, Min: bound(0), Default: "0", Description: "The maximum random jitter subtracted from the segment roll time"},
+	{Key: "segment.ms", Type: ConfigTypeLong, Min: bound(1), Default: "604800000", Description: "The period of time after which Kafka forces the log to roll"},
+	{Key: "unclean.leader.election.enable", Type: ConfigTypeBoolean, Default: "false", Description: "Whether replicas not in the ISR can be elected as leader as a last resort"},
+}
+
+// KnownConfigKeys returns the keys of all known topic configs
+func KnownConfigKeys() []string {
+	keys := make([]string, len(KnownConfigs))
+	for i, c := range KnownConfigs {
+		keys[i] = c.Key
+	}
+	return keys
+}
+
+// FindConfig returns the definition of a known topic config
+func FindConfig(key string) (*ConfigDefinition, bool) {
+	for i := range KnownConfigs {
+		if KnownConfigs[i].Key == key {
+			return &KnownConfigs[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsDefaultConfigValue returns true when value is the default value of a known topic config
+func IsDefaultConfigValue(key string, value string) bool {
+	def, ok := FindConfig(key)
+	return ok && def.Default == value
+}
+
+// ValidateConfigEntry checks that key is a known topic config and that value is valid for it
+func (v *Validator) ValidateConfigEntry(key string, value string) error {
+	def, ok := FindConfig(key)
+	if !ok {
+		keyTmplPair := localize.NewEntry("Key", key)
+		if suggestions := kafka.SuggestNames(key, KnownConfigKeys()); len(suggestions) > 0 {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.unknownKeyWithSuggestions", keyTmplPair, localize.NewEntry("Suggestions", `"`+strings.Join(suggestions, `" or "`)+`"`)))
+		}
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.unknownKey", keyTmplPair))
+	}
+
+	keyTmplPair := localize.NewEntry("Key", key)
+	valueTmplPair := localize.NewEntry("Value", value)
+
+	if len(def.ValidValues) > 0 {
+		for _, valid := range def.ValidValues {
+			if value == valid {
+				return nil
+			}
+		}
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.invalidEnum", keyTmplPair, valueTmplPair, localize.NewEntry("ValidValues", strings.Join(def.ValidValues, ", "))))
+	}
+
+	var number float64
+	switch def.Type {
+	case ConfigTypeInt, ConfigTypeLong:
+		bitSize := 64
+		if def.Type == ConfigTypeInt {
+			bitSize = 32
+		}
+		n, err := strconv.ParseInt(value, 10, bitSize)
+		if err != nil {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.invalidType", keyTmplPair, valueTmplPair, localize.NewEntry("Type", def.Type)))
+		}
+		number = float64(n)
+	case ConfigTypeDouble:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.invalidType", keyTmplPair, valueTmplPair, localize.NewEntry("Type", def.Type)))
+		}
+		number = n
+	case ConfigTypeBoolean:
+		if value != "true" && value != "false" {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.invalidType", keyTmplPair, valueTmplPair, localize.NewEntry("Type", def.Type)))
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	if def.Min != nil && number < *def.Min {
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.minValue", keyTmplPair, valueTmplPair, localize.NewEntry("Min", *def.Min)))
+	}
+	if def.Max != nil && number > *def.Max {
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.config.error.maxValue", keyTmplPair, valueTmplPair, localize.NewEntry("Max", *def.Max)))
+	}
+
+	return nil
+}
+
+// ValidateConfigEntries validates every entry of a config entry map
+func (v *Validator) ValidateConfigEntries(entries map[string]string) error {
+	for _, key := range sortedKeys(entries) {
+		if err := v.ValidateConfigEntry(key, entries[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseConfigEntries parses a list of "key=value" pairs into a config entry map
+func ParseConfigEntries(localizer localize.Localizer, pairs []string) (map[string]string, error) {
+	entries := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := splitConfigPair(pair)
+		if !ok {
+			return nil, errors.New(localizer.LocalizeByID("kafka.topic.common.config.error.invalidFormat", localize.NewEntry("Entry", pair)))
+		}
+		if _, exists := entries[key]; exists {
+			return nil, errors.New(localizer.LocalizeByID("kafka.topic.common.config.error.duplicateKey", localize.NewEntry("Key", key)))
+		}
+		entries[key] = value
+	}
+	return entries, nil
+}
+
+// ReadConfigFile reads a config entry map from a YAML or JSON file,
+// or from a properties file with one "key=value" pair per line
+func ReadConfigFile(localizer localize.Localizer, path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(localizer.LocalizeByID("kafka.topic.common.config.error.readFile", localize.NewEntry("Path", path), localize.NewEntry("Error", err)))
+	}
+
+	parseErr := func() error {
+		return errors.New(localizer.LocalizeByID("kafka.topic.common.config.error.parseFile", localize.NewEntry("Path", path)))
+	}
+
+	raw := map[string]interface{}{}
+	if err = yaml.Unmarshal(data, &raw); err != nil {
+		// fall back to the properties format
+		lines := []string{}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+		entries, err := ParseConfigEntries(localizer, lines)
+		if err != nil {
+			return nil, parseErr()
+		}
+		return entries, nil
+	}
+
+	entries := map[string]string{}
+	for key, value := range raw {
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}, nil:
+			return nil, parseErr()
+		}
+		entries[key] = fmt.Sprintf("%v", value)
+	}
+	return entries, nil
+}
+
+// MergeConfigEntries merges config entry maps, later maps taking precedence
+func MergeConfigEntries(maps ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, m := range maps {
+		for key, value := range m {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// ToConfigEntryMap converts a config entry map to the pointer map accepted by CreateConfigEntries
+func ToConfigEntryMap(entries map[string]string) map[string]*string {
+	entryMap := map[string]*string{}
+	for key, value := range entries {
+		value := value
+		entryMap[key] = &value
+	}
+	return entryMap
+}
+
+func splitConfigPair(pair string) (key string, value string, ok bool) {
+	i := strings.Index(pair, "=")
+	if i < 1 {
+		return "", "", false
+	}
+	return strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:]), true
+}
+
+// ConfigKeyFlags maps the topic configs which have a dedicated flag to the name of the flag
+var ConfigKeyFlags = map[string]string{
+	RetentionMsKey:   "retention-ms",
+	RetentionSizeKey: "retention-bytes",
+	CleanupPolicy:    "cleanup-policy",
+}
+
+// LoadConfigEntries reads the config entries from configFile and from the "key=value" pairs and validates them.
+// The pairs take precedence over the entries of the file.
+func LoadConfigEntries(localizer localize.Localizer, pairs []string, configFile string) (map[string]string, error) {
+	fileEntries := map[string]string{}
+	if configFile != "" {
+		var err error
+		if fileEntries, err = ReadConfigFile(localizer, configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	pairEntries, err := ParseConfigEntries(localizer, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := MergeConfigEntries(fileEntries, pairEntries)
+
+	validator := Validator{Localizer: localizer}
+	if err = validator.ValidateConfigEntries(entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// ValidateConfigFlagConflicts checks that no config entry is also set by its dedicated flag
+func ValidateConfigFlagConflicts(localizer localize.Localizer, entries map[string]string, changed func(flag string) bool) error {
+	for key, flagName := range ConfigKeyFlags {
+		if _, ok := entries[key]; ok && changed(flagName) {
+			return errors.New(localizer.LocalizeByID("kafka.topic.common.config.error.flagConflict", localize.NewEntry("Key", key), localize.NewEntry("Flag", flagName)))
+		}
+	}
+	return nil
+}
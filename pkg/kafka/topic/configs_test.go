@@ -0,0 +1,184 @@
+package topic
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateConfigEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:  "Should be valid for an int within range",
+			key:   "min.insync.replicas",
+			value: "2",
+		},
+		{
+			name:    "Should be invalid for an int below the minimum",
+			key:     "min.insync.replicas",
+			value:   "0",
+			wantErr: true,
+		},
+		{
+			name:    "Should be invalid for an int out of the 32 bit range",
+			key:     "max.message.bytes",
+			value:   "4294967296",
+			wantErr: true,
+		},
+		{
+			name:  "Should be valid for a long",
+			key:   "segment.ms",
+			value: "4294967296",
+		},
+		{
+			name:    "Should be invalid for a value which is not a number",
+			key:     "segment.ms",
+			value:   "1d",
+			wantErr: true,
+		},
+		{
+			name:  "Should be valid for a double within range",
+			key:   "min.cleanable.dirty.ratio",
+			value: "0.5",
+		},
+		{
+			name:    "Should be invalid for a double above the maximum",
+			key:     "min.cleanable.dirty.ratio",
+			value:   "1.5",
+			wantErr: true,
+		},
+		{
+			name:  "Should be valid for a known enum value",
+			key:   "compression.type",
+			value: "zstd",
+		},
+		{
+			name:    "Should be invalid for an unknown enum value",
+			key:     "compression.type",
+			value:   "brotli",
+			wantErr: true,
+		},
+		{
+			name:    "Should be invalid for a boolean which is not true or false",
+			key:     "preallocate",
+			value:   "yes",
+			wantErr: true,
+		},
+		{
+			name:    "Should be invalid for an unknown key",
+			key:     "min.insync.replica",
+			value:   "2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validator.ValidateConfigEntry(tt.key, tt.value); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfigEntry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseConfigEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:  "Should parse key value pairs",
+			pairs: []string{"segment.ms=1000", "cleanup.policy=compact,delete"},
+			want:  map[string]string{"segment.ms": "1000", "cleanup.policy": "compact,delete"},
+		},
+		{
+			name:    "Should fail without a separator",
+			pairs:   []string{"segment.ms"},
+			wantErr: true,
+		},
+		{
+			name:    "Should fail without a key",
+			pairs:   []string{"=1000"},
+			wantErr: true,
+		},
+		{
+			name:    "Should fail on a duplicate key",
+			pairs:   []string{"segment.ms=1000", "segment.ms=2000"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConfigEntries(validator.Localizer, tt.pairs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConfigEntries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseConfigEntries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "Should read a YAML file",
+			content: "min.insync.replicas: 2\npreallocate: true\n",
+			want:    map[string]string{"min.insync.replicas": "2", "preallocate": "true"},
+		},
+		{
+			name:    "Should read a JSON file",
+			content: `{"compression.type": "zstd", "segment.ms": 1000}`,
+			want:    map[string]string{"compression.type": "zstd", "segment.ms": "1000"},
+		},
+		{
+			name:    "Should read a properties file",
+			content: "# topic configs\nsegment.ms=1000\ncompression.type=lz4\n",
+			want:    map[string]string{"segment.ms": "1000", "compression.type": "lz4"},
+		},
+		{
+			name:    "Should fail on nested values",
+			content: "segment:\n  ms: 1000\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "topic-config")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "config")
+			if err = ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadConfigFile(validator.Localizer, path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadConfigFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadConfigFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package topic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/cmdutil/listutil"
+	"github.com/aerogear/charmil/core/utils/localize"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+	"gopkg.in/yaml.v2"
+)
+
+// fetchAllPageSize is the page size used when fetching all topics of an instance
+const fetchAllPageSize = 100
+
+// TopicSpec is the definition of a topic in a topic manifest
+type TopicSpec struct {
+	Name       string            `json:"name" yaml:"name"`
+	Partitions int32             `json:"partitions,omitempty" yaml:"partitions,omitempty"`
+	Config     map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// Manifest is a list of topic definitions
+type Manifest struct {
+	Topics []TopicSpec `json:"topics" yaml:"topics"`
+}
+
+// ReadManifest reads a topic manifest in YAML or JSON format.
+// The manifest is either a list of topics or a map with a "topics" list.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err = yaml.UnmarshalStrict(data, &manifest.Topics); err == nil {
+		return manifest, nil
+	}
+
+	manifest = &Manifest{}
+	if err = yaml.UnmarshalStrict(data, manifest); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// ValidateManifest validates the names, partitions and config entries of the topics in a manifest
+func (v *Validator) ValidateManifest(manifest *Manifest) error {
+	seen := map[string]bool{}
+	for _, spec := range manifest.Topics {
+		if err := v.ValidateName(spec.Name); err != nil {
+			return err
+		}
+		if seen[spec.Name] {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.manifest.error.duplicateTopic", localize.NewEntry("TopicName", spec.Name)))
+		}
+		seen[spec.Name] = true
+
+		if spec.Partitions != 0 {
+			if err := v.ValidatePartitionsN(spec.Partitions); err != nil {
+				return err
+			}
+		}
+		if err := v.ValidateConfigEntries(spec.Config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsInternalTopic returns true for the internal topics of Kafka and its operators, which start with "__"
+func IsInternalTopic(name string) bool {
+	return strings.HasPrefix(name, "__")
+}
+
+// FetchAllTopics fetches every page of topics of a Kafka instance.
+// The topics can be filtered by a search term which is passed to the API.
+func FetchAllTopics(ctx context.Context, api *kafkainstanceclient.APIClient, search string) ([]kafkainstanceclient.Topic, *http.Response, error) {
+	topics := []kafkainstanceclient.Topic{}
+	var httpRes *http.Response
+
+	err := listutil.FetchAll(func(page int32) (int, int, error) {
+		req := api.TopicsApi.GetTopics(ctx).Page(page).Size(fetchAllPageSize)
+		if search != "" {
+			req = req.Filter(search)
+		}
+
+		data, res, err := req.Execute()
+		httpRes = res
+		if res != nil {
+			defer res.Body.Close()
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		topics = append(topics, data.GetItems()...)
+		return len(data.GetItems()), int(data.GetTotal()), nil
+	})
+
+	return topics, httpRes, err
+}
+
+// ConfigEntriesToMap converts the config entries of a topic to a map
+func ConfigEntriesToMap(entries []kafkainstanceclient.ConfigEntry) map[string]string {
+	m := map[string]string{}
+	for _, entry := range entries {
+		m[entry.GetKey()] = entry.GetValue()
+	}
+	return m
+}
+
+// NewTopicSpec converts a live topic to a manifest entry.
+// Only the known config entries which differ from their default value are kept.
+func NewTopicSpec(topic kafkainstanceclient.Topic) TopicSpec {
+	spec := TopicSpec{
+		Name:       topic.GetName(),
+		Partitions: int32(len(topic.GetPartitions())),
+	}
+
+	for _, entry := range topic.GetConfig() {
+		key, value := entry.GetKey(), entry.GetValue()
+		if _, known := FindConfig(key); !known || IsDefaultConfigValue(key, value) {
+			continue
+		}
+		if spec.Config == nil {
+			spec.Config = map[string]string{}
+		}
+		spec.Config[key] = value
+	}
+
+	return spec
+}
+
+// ResetOverriddenConfig sets the config entries which the target topic overrides and the spec
+// leaves unset to their default value, so that applying the spec to the target resets them.
+// Entries which the target leaves at their default are not added, as they need no change.
+func ResetOverriddenConfig(spec *TopicSpec, target kafkainstanceclient.Topic) {
+	for _, entry := range target.GetConfig() {
+		key, value := entry.GetKey(), entry.GetValue()
+		def, known := FindConfig(key)
+		if !known || IsDefaultConfigValue(key, value) {
+			continue
+		}
+		if _, set := spec.Config[key]; set {
+			continue
+		}
+		if spec.Config == nil {
+			spec.Config = map[string]string{}
+		}
+		spec.Config[key] = def.Default
+	}
+}
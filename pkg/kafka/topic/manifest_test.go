@@ -0,0 +1,127 @@
+package topic
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []TopicSpec
+		wantErr bool
+	}{
+		{
+			name:    "Should read a list of topics",
+			content: "- name: orders\n  partitions: 3\n  config:\n    min.insync.replicas: 2\n",
+			want:    []TopicSpec{{Name: "orders", Partitions: 3, Config: map[string]string{"min.insync.replicas": "2"}}},
+		},
+		{
+			name:    "Should read a map with a topics list",
+			content: "topics:\n  - name: orders\n",
+			want:    []TopicSpec{{Name: "orders"}},
+		},
+		{
+			name:    "Should read JSON",
+			content: `{"topics": [{"name": "orders", "partitions": 1}]}`,
+			want:    []TopicSpec{{Name: "orders", Partitions: 1}},
+		},
+		{
+			name:    "Should fail on unknown fields",
+			content: "- name: orders\n  replicas: 3\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadManifest(strings.NewReader(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.Topics, tt.want) {
+				t.Errorf("ReadManifest() = %v, want %v", got.Topics, tt.want)
+			}
+		})
+	}
+}
+
+// nolint:funlen
+func TestNewTopicSpec(t *testing.T) {
+	topic := newTestTopic("orders", 3, map[string]string{
+		RetentionMsKey:        "86400000",
+		CleanupPolicy:         "delete",
+		"min.insync.replicas": "2",
+		"unknown.config":      "1",
+	})
+
+	want := TopicSpec{
+		Name:       "orders",
+		Partitions: 3,
+		Config: map[string]string{
+			RetentionMsKey:        "86400000",
+			"min.insync.replicas": "2",
+		},
+	}
+
+	if got := NewTopicSpec(topic); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewTopicSpec() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResetOverriddenConfig(t *testing.T) {
+	source := newTestTopic("orders", 3, map[string]string{
+		RetentionMsKey:        "604800000",
+		CleanupPolicy:         "compact",
+		"preallocate":         "false",
+		"unknown.config":      "1",
+		"min.insync.replicas": "1",
+		"segment.jitter.ms":   "0",
+	})
+
+	spec := NewTopicSpec(source)
+	if !reflect.DeepEqual(spec.Config, map[string]string{CleanupPolicy: "compact"}) {
+		t.Fatalf("NewTopicSpec() config = %v, want only the non-default entries", spec.Config)
+	}
+
+	// a target which overrides a config entry left at its default by the source is reset
+	target := newTestTopic("orders", 3, map[string]string{
+		RetentionMsKey:        "86400000",
+		CleanupPolicy:         "delete",
+		"preallocate":         "false",
+		"min.insync.replicas": "2",
+		"unknown.config":      "2",
+	})
+
+	ResetOverriddenConfig(&spec, target)
+
+	want := map[string]string{
+		CleanupPolicy:         "compact",
+		RetentionMsKey:        "604800000",
+		"min.insync.replicas": "1",
+	}
+	if !reflect.DeepEqual(spec.Config, want) {
+		t.Fatalf("ResetOverriddenConfig() config = %v, want %v", spec.Config, want)
+	}
+
+	validator := &Validator{}
+	plan, err := validator.NewPlan(&Manifest{Topics: []TopicSpec{spec}}, []kafkainstanceclient.Topic{target}, false)
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+
+	wantChanges := []Change{
+		{Action: ActionUpdate, Topic: "orders", Config: []ConfigChange{
+			{Key: CleanupPolicy, From: "delete", To: "compact"},
+			{Key: "min.insync.replicas", From: "2", To: "1"},
+			{Key: RetentionMsKey, From: "86400000", To: "604800000"},
+		}},
+	}
+	if !reflect.DeepEqual(plan.Changes, wantChanges) {
+		t.Errorf("NewPlan() = %+v, want %+v", plan.Changes, wantChanges)
+	}
+}
@@ -0,0 +1,75 @@
+package topic
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/aerogear/charmil-host-example/pkg/common/commonerr"
+	"github.com/aerogear/charmil/core/utils/localize"
+)
+
+// ValidateNamingPolicy checks that the name of a new topic follows the naming policy of the validator.
+// Every pattern must match, the name must start with one of the prefixes,
+// no forbidden pattern may match and the name must not exceed the maximum length.
+func (v *Validator) ValidateNamingPolicy(val interface{}) error {
+	name, ok := val.(string)
+	if !ok {
+		return commonerr.NewCastError(val, "string")
+	}
+
+	policy := v.NamingPolicy
+	if policy == nil {
+		return nil
+	}
+
+	nameTmplPair := localize.NewEntry("Name", name)
+
+	if policy.MaxLength > 0 && len(name) > policy.MaxLength {
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.validation.name.error.policyMaxLength", nameTmplPair, localize.NewEntry("MaxLength", policy.MaxLength)))
+	}
+
+	if len(policy.Prefixes) > 0 {
+		hasPrefix := false
+		for _, prefix := range policy.Prefixes {
+			if strings.HasPrefix(name, prefix) {
+				hasPrefix = true
+				break
+			}
+		}
+		if !hasPrefix {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.validation.name.error.policyPrefix", nameTmplPair, localize.NewEntry("Prefixes", `"`+strings.Join(policy.Prefixes, `", "`)+`"`)))
+		}
+	}
+
+	for _, pattern := range policy.Patterns {
+		matched, err := v.matchPolicyPattern(pattern, name)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.validation.name.error.policyPattern", nameTmplPair, localize.NewEntry("Pattern", pattern)))
+		}
+	}
+
+	for _, pattern := range policy.ForbiddenPatterns {
+		matched, err := v.matchPolicyPattern(pattern, name)
+		if err != nil {
+			return err
+		}
+		if matched {
+			return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.validation.name.error.policyForbiddenPattern", nameTmplPair, localize.NewEntry("Pattern", pattern)))
+		}
+	}
+
+	return nil
+}
+
+// matchPolicyPattern reports whether the name matches a regular expression of the naming policy
+func (v *Validator) matchPolicyPattern(pattern string, name string) (bool, error) {
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, errors.New(v.Localizer.LocalizeByID("kafka.topic.common.validation.name.error.policyInvalidPattern", localize.NewEntry("Pattern", pattern), localize.NewEntry("Error", err)))
+	}
+	return r.MatchString(name), nil
+}
@@ -0,0 +1,92 @@
+package topic
+
+import (
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/pkg/config"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func TestValidateNamingPolicy(t *testing.T) {
+	policy := &config.TopicNamingPolicy{
+		Patterns:          []string{`^[a-z]+\.[a-z]+\.[a-z-]+\.v[0-9]+$`},
+		Prefixes:          []string{"payments.", "orders."},
+		ForbiddenPatterns: []string{"test"},
+		MaxLength:         40,
+	}
+
+	tests := []struct {
+		name    string
+		policy  *config.TopicNamingPolicy
+		topic   string
+		wantErr bool
+	}{
+		{
+			name:   "Should be valid without a naming policy",
+			policy: nil,
+			topic:  "anything",
+		},
+		{
+			name:   "Should be valid when every rule passes",
+			policy: policy,
+			topic:  "payments.cards.authorized.v1",
+		},
+		{
+			name:    "Should fail when the name has no required prefix",
+			policy:  policy,
+			topic:   "billing.cards.authorized.v1",
+			wantErr: true,
+		},
+		{
+			name:    "Should fail when the name does not match a pattern",
+			policy:  policy,
+			topic:   "payments.cards.authorized",
+			wantErr: true,
+		},
+		{
+			name:    "Should fail when the name matches a forbidden pattern",
+			policy:  policy,
+			topic:   "orders.test.created.v1",
+			wantErr: true,
+		},
+		{
+			name:    "Should fail when the name is too long",
+			policy:  policy,
+			topic:   "payments.cards.authorized-with-a-long-name.v1",
+			wantErr: true,
+		},
+		{
+			name:    "Should fail when a pattern of the policy is invalid",
+			policy:  &config.TopicNamingPolicy{Patterns: []string{"["}},
+			topic:   "orders",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			v := &Validator{Localizer: validator.Localizer, NamingPolicy: tt.policy}
+			if err := v.ValidateNamingPolicy(tt.topic); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNamingPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewPlanNamingPolicy(t *testing.T) {
+	v := &Validator{
+		Localizer:    validator.Localizer,
+		NamingPolicy: &config.TopicNamingPolicy{Prefixes: []string{"orders."}},
+	}
+
+	// existing topics are not checked against the policy
+	live := []kafkainstanceclient.Topic{newTestTopic("legacy", 1, nil)}
+
+	if _, err := v.NewPlan(&Manifest{Topics: []TopicSpec{{Name: "legacy", Partitions: 2}}}, live, false); err != nil {
+		t.Errorf("NewPlan() error = %v for an existing topic", err)
+	}
+
+	if _, err := v.NewPlan(&Manifest{Topics: []TopicSpec{{Name: "payments"}}}, live, false); err == nil {
+		t.Errorf("NewPlan() error = nil for a new topic which breaks the naming policy")
+	}
+}
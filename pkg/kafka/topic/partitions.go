@@ -0,0 +1,58 @@
+package topic
+
+import (
+	"fmt"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// NodeID returns the ID of a broker node as returned by the API, or "-" when it is missing
+func NodeID(node map[string]interface{}) string {
+	id, ok := node["id"]
+	if !ok || id == nil {
+		return "-"
+	}
+
+	// numbers are decoded from JSON as float64
+	if f, ok := id.(float64); ok {
+		return fmt.Sprintf("%v", int64(f))
+	}
+
+	return fmt.Sprintf("%v", id)
+}
+
+// NodeIDs returns the IDs of a list of broker nodes
+func NodeIDs(nodes []map[string]interface{}) []string {
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = NodeID(node)
+	}
+	return ids
+}
+
+// IsUnderReplicated checks if fewer replicas of the partition are in sync than it has in total
+func IsUnderReplicated(partition kafkainstanceclient.Partition) bool {
+	return len(partition.GetIsr()) < len(partition.GetReplicas())
+}
+
+// ReplicationFactor returns the highest number of replicas of the partitions of the topic
+func ReplicationFactor(topic kafkainstanceclient.Topic) int {
+	factor := 0
+	for _, partition := range topic.GetPartitions() {
+		if n := len(partition.GetReplicas()); n > factor {
+			factor = n
+		}
+	}
+	return factor
+}
+
+// CountUnderReplicated returns the number of under-replicated partitions of the topic
+func CountUnderReplicated(topic kafkainstanceclient.Topic) int {
+	count := 0
+	for _, partition := range topic.GetPartitions() {
+		if IsUnderReplicated(partition) {
+			count++
+		}
+	}
+	return count
+}
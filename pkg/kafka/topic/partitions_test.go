@@ -0,0 +1,65 @@
+package topic
+
+import (
+	"testing"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func newTestPartition(id int32, replicas []float64, isr []float64) kafkainstanceclient.Partition {
+	toNodes := func(ids []float64) []map[string]interface{} {
+		nodes := make([]map[string]interface{}, len(ids))
+		for i, id := range ids {
+			nodes[i] = map[string]interface{}{"id": id}
+		}
+		return nodes
+	}
+
+	partition := kafkainstanceclient.Partition{Id: id}
+	partition.SetReplicas(toNodes(replicas))
+	partition.SetIsr(toNodes(isr))
+	if len(isr) > 0 {
+		partition.SetLeader(map[string]interface{}{"id": isr[0]})
+	}
+	return partition
+}
+
+func TestNodeID(t *testing.T) {
+	tests := []struct {
+		name string
+		node map[string]interface{}
+		want string
+	}{
+		{name: "Should format a decoded JSON number", node: map[string]interface{}{"id": float64(2)}, want: "2"},
+		{name: "Should format an int", node: map[string]interface{}{"id": 3}, want: "3"},
+		{name: "Should return a dash when the ID is missing", node: map[string]interface{}{}, want: "-"},
+		{name: "Should return a dash for a nil node", node: nil, want: "-"},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NodeID(tt.node); got != tt.want {
+				t.Errorf("NodeID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopicReplication(t *testing.T) {
+	topic := kafkainstanceclient.Topic{}
+	topic.SetPartitions([]kafkainstanceclient.Partition{
+		newTestPartition(0, []float64{0, 1, 2}, []float64{0, 1, 2}),
+		newTestPartition(1, []float64{1, 2, 0}, []float64{1}),
+		newTestPartition(2, []float64{2, 0, 1}, []float64{2, 0}),
+	})
+
+	if got := ReplicationFactor(topic); got != 3 {
+		t.Errorf("ReplicationFactor() = %v, want 3", got)
+	}
+	if got := CountUnderReplicated(topic); got != 2 {
+		t.Errorf("CountUnderReplicated() = %v, want 2", got)
+	}
+	if IsUnderReplicated(topic.GetPartitions()[0]) {
+		t.Errorf("IsUnderReplicated() = true for a partition with all replicas in sync")
+	}
+}
@@ -0,0 +1,48 @@
+package topic
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NamePattern matches topic names against a glob or a regular expression
+type NamePattern struct {
+	re *regexp.Regexp
+}
+
+// NewNamePattern compiles a topic name pattern.
+// A glob supports "*" for any sequence of characters and "?" for a single character.
+// When regex is true, the pattern is a regular expression which must match the whole name.
+func NewNamePattern(pattern string, regex bool) (*NamePattern, error) {
+	expr := pattern
+	if !regex {
+		expr = globToRegexp(pattern)
+	}
+
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, err
+	}
+
+	return &NamePattern{re: re}, nil
+}
+
+// Match returns true when name matches the pattern
+func (p *NamePattern) Match(name string) bool {
+	return p.re.MatchString(name)
+}
+
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package topic
+
+import "testing"
+
+func TestNamePattern(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		regex     bool
+		topicName string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "Should match a glob with a wildcard",
+			pattern:   "orders-*",
+			topicName: "orders-eu",
+			want:      true,
+		},
+		{
+			name:      "Should match the whole name with a glob",
+			pattern:   "orders",
+			topicName: "orders-eu",
+			want:      false,
+		},
+		{
+			name:      "Should treat regular expression characters in a glob literally",
+			pattern:   "orders.?u",
+			topicName: "orders-eu",
+			want:      false,
+		},
+		{
+			name:      "Should match a regular expression",
+			pattern:   "orders-(eu|us)",
+			regex:     true,
+			topicName: "orders-us",
+			want:      true,
+		},
+		{
+			name:    "Should fail on an invalid regular expression",
+			pattern: "orders-(",
+			regex:   true,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := NewNamePattern(tt.pattern, tt.regex)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewNamePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := pattern.Match(tt.topicName); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
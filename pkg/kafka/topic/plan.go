@@ -0,0 +1,164 @@
+package topic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aerogear/charmil/core/utils/localize"
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+// defaultPartitions is the number of partitions of a new topic when the manifest does not set it
+const defaultPartitions = 1
+
+// ChangeAction is the action applied to a topic
+type ChangeAction string
+
+const (
+	ActionCreate ChangeAction = "create"
+	ActionUpdate ChangeAction = "update"
+	ActionDelete ChangeAction = "delete"
+)
+
+// ConfigChange is a change to the value of a config entry
+type ConfigChange struct {
+	Key  string `json:"key" yaml:"key"`
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	To   string `json:"to" yaml:"to"`
+}
+
+// Change is a planned change to a single topic
+type Change struct {
+	Action         ChangeAction   `json:"action" yaml:"action"`
+	Topic          string         `json:"topic" yaml:"topic"`
+	FromPartitions int32          `json:"fromPartitions,omitempty" yaml:"fromPartitions,omitempty"`
+	ToPartitions   int32          `json:"toPartitions,omitempty" yaml:"toPartitions,omitempty"`
+	Config         []ConfigChange `json:"config,omitempty" yaml:"config,omitempty"`
+}
+
+// Plan is the list of changes needed to bring a Kafka instance to the state of a manifest
+type Plan struct {
+	Changes []Change `json:"changes" yaml:"changes"`
+}
+
+// IsEmpty returns true when the plan has no changes
+func (p *Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the given action
+func (p *Plan) Count(action ChangeAction) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary describes the partitions and config entries of a change in a single line
+func (c *Change) Summary() string {
+	parts := []string{}
+	switch c.Action {
+	case ActionCreate:
+		parts = append(parts, fmt.Sprintf("partitions=%v", c.ToPartitions))
+		for _, cfg := range c.Config {
+			parts = append(parts, fmt.Sprintf("%v=%v", cfg.Key, cfg.To))
+		}
+	case ActionUpdate:
+		if c.ToPartitions != 0 {
+			parts = append(parts, fmt.Sprintf("partitions: %v -> %v", c.FromPartitions, c.ToPartitions))
+		}
+		for _, cfg := range c.Config {
+			from := cfg.From
+			if from == "" {
+				from = "(unset)"
+			}
+			parts = append(parts, fmt.Sprintf("%v: %v -> %v", cfg.Key, from, cfg.To))
+		}
+	case ActionDelete:
+		parts = append(parts, fmt.Sprintf("partitions=%v", c.FromPartitions))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// NewPlan compares the topics of a manifest with the live topics of an instance.
+// Topics missing from the instance are created, and topics with different partitions or config entries are updated.
+// Config entries which are not in the manifest are left unchanged.
+// When prune is true, the live topics which are not in the manifest are deleted, except internal topics.
+// The partitions of a topic cannot be reduced, which is checked by the Validator.
+func (v *Validator) NewPlan(manifest *Manifest, live []kafkainstanceclient.Topic, prune bool) (*Plan, error) {
+	liveByName := map[string]kafkainstanceclient.Topic{}
+	for _, t := range live {
+		liveByName[t.GetName()] = t
+	}
+
+	plan := &Plan{}
+	managed := map[string]bool{}
+	for _, spec := range manifest.Topics {
+		managed[spec.Name] = true
+
+		topic, exists := liveByName[spec.Name]
+		if !exists {
+			// the naming policy only applies to new topics
+			if err := v.ValidateNamingPolicy(spec.Name); err != nil {
+				return nil, errors.New(v.Localizer.LocalizeByID("kafka.topic.common.manifest.error.invalidTopic", localize.NewEntry("TopicName", spec.Name), localize.NewEntry("Error", err)))
+			}
+			partitions := spec.Partitions
+			if partitions == 0 {
+				partitions = defaultPartitions
+			}
+			change := Change{Action: ActionCreate, Topic: spec.Name, ToPartitions: partitions}
+			for _, key := range sortedKeys(spec.Config) {
+				change.Config = append(change.Config, ConfigChange{Key: key, To: spec.Config[key]})
+			}
+			plan.Changes = append(plan.Changes, change)
+			continue
+		}
+
+		change := Change{Action: ActionUpdate, Topic: spec.Name}
+		curPartitions := int32(len(topic.GetPartitions()))
+		if spec.Partitions != 0 && spec.Partitions != curPartitions {
+			partitionsValidator := Validator{Localizer: v.Localizer, CurPartitions: int(curPartitions)}
+			if err := partitionsValidator.ValidatePartitionsN(spec.Partitions); err != nil {
+				return nil, errors.New(v.Localizer.LocalizeByID("kafka.topic.common.manifest.error.invalidTopic", localize.NewEntry("TopicName", spec.Name), localize.NewEntry("Error", err)))
+			}
+			change.FromPartitions = curPartitions
+			change.ToPartitions = spec.Partitions
+		}
+
+		liveConfig := ConfigEntriesToMap(topic.GetConfig())
+		for _, key := range sortedKeys(spec.Config) {
+			if liveConfig[key] != spec.Config[key] {
+				change.Config = append(change.Config, ConfigChange{Key: key, From: liveConfig[key], To: spec.Config[key]})
+			}
+		}
+
+		if change.ToPartitions != 0 || len(change.Config) > 0 {
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	if prune {
+		for _, t := range live {
+			if managed[t.GetName()] || IsInternalTopic(t.GetName()) {
+				continue
+			}
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Topic: t.GetName(), FromPartitions: int32(len(t.GetPartitions()))})
+		}
+	}
+
+	return plan, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package topic
+
+import (
+	"reflect"
+	"testing"
+
+	kafkainstanceclient "github.com/redhat-developer/app-services-sdk-go/kafkainstance/apiv1internal/client"
+)
+
+func newTestTopic(name string, partitions int, config map[string]string) kafkainstanceclient.Topic {
+	topic := kafkainstanceclient.Topic{}
+	topic.SetName(name)
+	topic.SetPartitions(make([]kafkainstanceclient.Partition, partitions))
+	topic.SetConfig(*CreateConfigEntries(ToConfigEntryMap(config)))
+	return topic
+}
+
+func TestNewPlan(t *testing.T) {
+	live := []kafkainstanceclient.Topic{
+		newTestTopic("orders", 3, map[string]string{RetentionMsKey: "604800000", CleanupPolicy: "delete"}),
+		newTestTopic("unmanaged", 1, nil),
+		newTestTopic("__consumer_offsets", 50, nil),
+	}
+
+	tests := []struct {
+		name     string
+		manifest *Manifest
+		prune    bool
+		want     []Change
+		wantErr  bool
+	}{
+		{
+			name: "Should plan a create for a missing topic with the default partitions",
+			manifest: &Manifest{Topics: []TopicSpec{
+				{Name: "payments", Config: map[string]string{"segment.ms": "1000"}},
+			}},
+			want: []Change{
+				{Action: ActionCreate, Topic: "payments", ToPartitions: 1, Config: []ConfigChange{{Key: "segment.ms", To: "1000"}}},
+			},
+		},
+		{
+			name: "Should plan an update for changed partitions and config entries only",
+			manifest: &Manifest{Topics: []TopicSpec{
+				{Name: "orders", Partitions: 6, Config: map[string]string{RetentionMsKey: "86400000", CleanupPolicy: "delete"}},
+			}},
+			want: []Change{
+				{Action: ActionUpdate, Topic: "orders", FromPartitions: 3, ToPartitions: 6, Config: []ConfigChange{{Key: RetentionMsKey, From: "604800000", To: "86400000"}}},
+			},
+		},
+		{
+			name: "Should not plan a change for a topic which matches the manifest",
+			manifest: &Manifest{Topics: []TopicSpec{
+				{Name: "orders", Partitions: 3, Config: map[string]string{CleanupPolicy: "delete"}},
+			}},
+			want: nil,
+		},
+		{
+			name: "Should fail when the partitions are reduced",
+			manifest: &Manifest{Topics: []TopicSpec{
+				{Name: "orders", Partitions: 2},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "Should plan deletes for unmanaged topics except internal topics when pruning",
+			manifest: &Manifest{Topics: []TopicSpec{
+				{Name: "orders"},
+			}},
+			prune: true,
+			want: []Change{
+				{Action: ActionDelete, Topic: "unmanaged", FromPartitions: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validator.NewPlan(tt.manifest, live, tt.prune)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.Changes, tt.want) {
+				t.Errorf("NewPlan() = %+v, want %+v", got.Changes, tt.want)
+			}
+		})
+	}
+}
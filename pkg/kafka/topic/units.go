@@ -0,0 +1,194 @@
+package topic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Unlimited is the human-friendly form of the retention value -1
+const Unlimited = "unlimited"
+
+var (
+	errInvalidUnitValue = errors.New("invalid value")
+
+	durationPartRegexp = regexp.MustCompile(`(\d+(?:\.\d+)?)(ms|s|m|h|d|w)`)
+	sizeRegexp         = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]+)$`)
+)
+
+var durationUnits = []struct {
+	suffix string
+	ms     int64
+}{
+	{"w", 7 * 24 * 60 * 60 * 1000},
+	{"d", 24 * 60 * 60 * 1000},
+	{"h", 60 * 60 * 1000},
+	{"m", 60 * 1000},
+	{"s", 1000},
+	{"ms", 1},
+}
+
+var sizeUnits = map[string]int64{
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+}
+
+var binarySizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+}
+
+// ParseRetentionMs parses a retention period in milliseconds.
+// It accepts a number of milliseconds, "unlimited" for -1,
+// or a duration such as "7d", "36h" or "1h30m" using the units ms, s, m, h, d and w.
+func ParseRetentionMs(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, Unlimited) {
+		return -1, nil
+	}
+
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ms, nil
+	}
+
+	matches := durationPartRegexp.FindAllStringSubmatchIndex(value, -1)
+	if len(matches) == 0 {
+		return 0, errInvalidUnitValue
+	}
+
+	var total float64
+	end := 0
+	for _, m := range matches {
+		// the parts must cover the whole value without gaps
+		if m[0] != end {
+			return 0, errInvalidUnitValue
+		}
+		end = m[1]
+
+		n, err := strconv.ParseFloat(value[m[2]:m[3]], 64)
+		if err != nil {
+			return 0, errInvalidUnitValue
+		}
+		unit := value[m[4]:m[5]]
+		for _, u := range durationUnits {
+			if u.suffix == unit {
+				total += n * float64(u.ms)
+			}
+		}
+	}
+	if end != len(value) || total > math.MaxInt64 {
+		return 0, errInvalidUnitValue
+	}
+
+	return int64(math.Round(total)), nil
+}
+
+// ParseRetentionBytes parses a retention size in bytes.
+// It accepts a number of bytes, "unlimited" for -1, or a size such as "500MiB" or "1GB"
+// using the decimal units B, KB, MB, GB and TB or the binary units KiB, MiB, GiB and TiB.
+func ParseRetentionBytes(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, Unlimited) {
+		return -1, nil
+	}
+
+	if bytes, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return bytes, nil
+	}
+
+	m := sizeRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return 0, errInvalidUnitValue
+	}
+
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, errInvalidUnitValue
+	}
+	unit, ok := sizeUnits[strings.ToLower(m[2])]
+	if !ok {
+		return 0, errInvalidUnitValue
+	}
+
+	total := n * float64(unit)
+	if total > math.MaxInt64 {
+		return 0, errInvalidUnitValue
+	}
+
+	return int64(math.Round(total)), nil
+}
+
+// FormatRetentionMs formats a retention period in milliseconds with the largest unit which represents it exactly
+func FormatRetentionMs(ms int64) string {
+	if ms < 0 {
+		return Unlimited
+	}
+	if ms == 0 {
+		return "0ms"
+	}
+
+	for _, u := range durationUnits {
+		// weeks are shown as days, which is how retention is usually expressed
+		if u.suffix == "w" {
+			continue
+		}
+		if ms%u.ms == 0 {
+			return fmt.Sprintf("%v%v", ms/u.ms, u.suffix)
+		}
+	}
+
+	return fmt.Sprintf("%vms", ms)
+}
+
+// FormatRetentionBytes formats a retention size in bytes with the largest binary unit,
+// rounded to one decimal when the size is not an exact multiple of the unit
+func FormatRetentionBytes(bytes int64) string {
+	if bytes < 0 {
+		return Unlimited
+	}
+
+	for _, u := range binarySizeUnits {
+		if bytes < u.bytes {
+			continue
+		}
+		if bytes%u.bytes == 0 {
+			return fmt.Sprintf("%v%v", bytes/u.bytes, u.suffix)
+		}
+		return fmt.Sprintf("%.1f%v", float64(bytes)/float64(u.bytes), u.suffix)
+	}
+
+	return fmt.Sprintf("%vB", bytes)
+}
+
+// FormatRetentionValue returns the human-friendly form of the raw value of a retention config entry,
+// or an empty string when the key is not a retention config or the value is not a number
+func FormatRetentionValue(key string, value string) string {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return ""
+	}
+
+	switch key {
+	case RetentionMsKey:
+		return FormatRetentionMs(n)
+	case RetentionSizeKey:
+		return FormatRetentionBytes(n)
+	default:
+		return ""
+	}
+}
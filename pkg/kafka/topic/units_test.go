@@ -0,0 +1,113 @@
+package topic
+
+import "testing"
+
+func TestParseRetentionMs(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "604800000", want: 604800000},
+		{value: "-1", want: -1},
+		{value: "unlimited", want: -1},
+		{value: "Unlimited", want: -1},
+		{value: "7d", want: 604800000},
+		{value: "36h", want: 129600000},
+		{value: "1h30m", want: 5400000},
+		{value: "2w", want: 1209600000},
+		{value: "1.5s", want: 1500},
+		{value: "500ms", want: 500},
+		{value: "3.0", wantErr: true},
+		{value: "7 days", wantErr: true},
+		{value: "h1", wantErr: true},
+		{value: "1hx", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRetentionMs(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRetentionMs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRetentionMs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetentionBytes(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "1073741824", want: 1073741824},
+		{value: "unlimited", want: -1},
+		{value: "500MiB", want: 524288000},
+		{value: "1GB", want: 1000000000},
+		{value: "1gib", want: 1073741824},
+		{value: "1.5 KiB", want: 1536},
+		{value: "100B", want: 100},
+		{value: "3.0", wantErr: true},
+		{value: "1XB", wantErr: true},
+		{value: "GB", wantErr: true},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseRetentionBytes(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRetentionBytes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRetentionBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRetentionMs(t *testing.T) {
+	tests := []struct {
+		ms   int64
+		want string
+	}{
+		{ms: -1, want: "unlimited"},
+		{ms: 0, want: "0ms"},
+		{ms: 604800000, want: "7d"},
+		{ms: 129600000, want: "36h"},
+		{ms: 5400000, want: "90m"},
+		{ms: 1500, want: "1500ms"},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatRetentionMs(tt.ms); got != tt.want {
+				t.Errorf("FormatRetentionMs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRetentionBytes(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{bytes: -1, want: "unlimited"},
+		{bytes: 100, want: "100B"},
+		{bytes: 524288000, want: "500MiB"},
+		{bytes: 1073741824, want: "1GiB"},
+		{bytes: 1000000000, want: "953.7MiB"},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatRetentionBytes(tt.bytes); got != tt.want {
+				t.Errorf("FormatRetentionBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
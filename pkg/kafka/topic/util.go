@@ -43,23 +43,25 @@ func ConvertPartitionsToInt(partitionStr string) (int32, error) {
 }
 
 // ConvertRetentionMsToInt converts the value from "retention-ms" to int
+// the value can be a number of milliseconds, "unlimited" or a duration such as "7d"
 func ConvertRetentionMsToInt(retentionMsStr string) (int, error) {
-	retentionMsInt, err := strconv.Atoi(retentionMsStr)
+	retentionMs, err := ParseRetentionMs(retentionMsStr)
 	if err != nil {
-		return 0, fmt.Errorf("invalid value for retention period: %v", retentionMsInt)
+		return 0, fmt.Errorf("invalid value for retention period: %v", retentionMsStr)
 	}
 
-	return retentionMsInt, nil
+	return int(retentionMs), nil
 }
 
 // ConvertRetentionBytesToInt converts the value from "retention-bytes" to int
+// the value can be a number of bytes, "unlimited" or a size such as "500MiB"
 func ConvertRetentionBytesToInt(retentionBytesStr string) (int, error) {
-	retentionMsInt, err := strconv.Atoi(retentionBytesStr)
+	retentionBytes, err := ParseRetentionBytes(retentionBytesStr)
 	if err != nil {
-		return 0, fmt.Errorf("invalid value for retention size: %v", retentionMsInt)
+		return 0, fmt.Errorf("invalid value for retention size: %v", retentionBytesStr)
 	}
 
-	return retentionMsInt, nil
+	return int(retentionBytes), nil
 }
 
 func GetConfigValue(configEntries []kafkainstanceclient.ConfigEntry, keyName string) (val string) {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/aerogear/charmil-host-example/pkg/cmd/factory"
 	"github.com/aerogear/charmil-host-example/pkg/common/commonerr"
+	"github.com/aerogear/charmil-host-example/pkg/config"
 	"github.com/aerogear/charmil-host-example/pkg/connection"
 	"github.com/aerogear/charmil/core/utils/localize"
 )
@@ -26,6 +27,8 @@ type Validator struct {
 	InstanceID    string
 	Connection    factory.ConnectionFunc
 	CurPartitions int
+	// NamingPolicy is checked for the names of new topics, when it is set
+	NamingPolicy *config.TopicNamingPolicy
 }
 
 // ValidateName validates the name of the topic
@@ -98,7 +101,7 @@ func (v *Validator) ValidatePartitionsN(val interface{}) error {
 }
 
 // ValidateMessageRetentionPeriod validates the value (ms) of the retention period
-// the valid values can range from [-1,...]
+// the valid values can range from [-1,...] and can be given as a duration such as "7d" or as "unlimited"
 func (v *Validator) ValidateMessageRetentionPeriod(val interface{}) error {
 	retentionPeriodMsStr := fmt.Sprintf("%v", val)
 
@@ -106,9 +109,9 @@ func (v *Validator) ValidateMessageRetentionPeriod(val interface{}) error {
 		return nil
 	}
 
-	retentionPeriodMs, err := strconv.Atoi(retentionPeriodMsStr)
+	retentionPeriodMs, err := ParseRetentionMs(retentionPeriodMsStr)
 	if err != nil {
-		return commonerr.NewCastError(val, "int")
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.input.retentionMs.error.invalid", localize.NewEntry("RetentionMs", retentionPeriodMsStr)))
 	}
 
 	if retentionPeriodMs < -1 {
@@ -119,7 +122,7 @@ func (v *Validator) ValidateMessageRetentionPeriod(val interface{}) error {
 }
 
 // ValidateMessageRetentionSize validates the value (bytes) of the retention size
-// the valid values can range from [-1,...]
+// the valid values can range from [-1,...] and can be given as a size such as "500MiB" or as "unlimited"
 func (v *Validator) ValidateMessageRetentionSize(val interface{}) error {
 	retentionSizeStr := fmt.Sprintf("%v", val)
 
@@ -127,9 +130,9 @@ func (v *Validator) ValidateMessageRetentionSize(val interface{}) error {
 		return nil
 	}
 
-	retentionPeriodBytes, err := strconv.Atoi(retentionSizeStr)
+	retentionPeriodBytes, err := ParseRetentionBytes(retentionSizeStr)
 	if err != nil {
-		return commonerr.NewCastError(val, "int")
+		return errors.New(v.Localizer.LocalizeByID("kafka.topic.common.input.retentionBytes.error.invalid", localize.NewEntry("RetentionBytes", retentionSizeStr)))
 	}
 
 	if retentionPeriodBytes < -1 {
@@ -328,6 +328,20 @@ func TestValidateMessageRetentionPeriod(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should be valid when a duration is passed",
+			args: args{
+				retentionMs: "7d",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should be valid when unlimited is passed",
+			args: args{
+				retentionMs: "unlimited",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -391,12 +405,26 @@ func TestValidateMessageRetentionSize(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should be valid when a size is passed",
+			args: args{
+				retentionBytes: "500MiB",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should be invalid when an unknown unit is passed",
+			args: args{
+				retentionBytes: "500XB",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// nolint
-			if err := validator.ValidateMessageRetentionPeriod(tt.args.retentionBytes); (err != nil) != tt.wantErr {
+			if err := validator.ValidateMessageRetentionSize(tt.args.retentionBytes); (err != nil) != tt.wantErr {
 				t.Errorf("ValidateMessageRetentionSize() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
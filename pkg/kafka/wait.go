@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+// Kafka instance statuses reported by the control plane
+const (
+	StatusAccepted     = "accepted"
+	StatusPreparing    = "preparing"
+	StatusProvisioning = "provisioning"
+	StatusReady        = "ready"
+	StatusFailed       = "failed"
+	StatusDeprovision  = "deprovision"
+	StatusDeleting     = "deleting"
+)
+
+var (
+	// WaitInitialInterval is the time to wait before polling the instance for the second time
+	WaitInitialInterval = 5 * time.Second
+	// WaitMaxInterval is the upper limit of the time between two polls
+	WaitMaxInterval = 30 * time.Second
+)
+
+// WaitForReady polls the Kafka instance until it has reached the "ready" or "failed" status,
+// or until it is being deleted, as it will then never become ready.
+// The polling interval starts at WaitInitialInterval and backs off up to WaitMaxInterval.
+// onPoll, if set, is called with the instance after every successful poll.
+// The context error is returned when the context is cancelled or its deadline is exceeded.
+func WaitForReady(ctx context.Context, api kafkamgmtclient.DefaultApi, id string, onPoll func(*kafkamgmtclient.KafkaRequest)) (*kafkamgmtclient.KafkaRequest, error) {
+	interval := WaitInitialInterval
+
+	for {
+		kafkaInstance, _, err := GetKafkaByID(ctx, api, id)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if onPoll != nil {
+			onPoll(kafkaInstance)
+		}
+
+		switch kafkaInstance.GetStatus() {
+		case StatusReady, StatusFailed, StatusDeprovision, StatusDeleting:
+			return kafkaInstance, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = nextWaitInterval(interval)
+	}
+}
+
+// nextWaitInterval increases the polling interval by half, up to WaitMaxInterval
+func nextWaitInterval(interval time.Duration) time.Duration {
+	next := interval + interval/2
+	if next > WaitMaxInterval {
+		return WaitMaxInterval
+	}
+
+	return next
+}
@@ -0,0 +1,133 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kafkamgmtclient "github.com/redhat-developer/app-services-sdk-go/kafkamgmt/apiv1/client"
+)
+
+func newKafkaStatusServer(statuses ...string) *httptest.Server {
+	polls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[len(statuses)-1]
+		if polls < len(statuses) {
+			status = statuses[polls]
+		}
+		polls++
+
+		kafkaReq := kafkamgmtclient.KafkaRequest{}
+		kafkaReq.SetId("1")
+		kafkaReq.SetName("my-kafka")
+		kafkaReq.SetStatus(status)
+		if status == StatusFailed {
+			kafkaReq.SetFailedReason("out of capacity")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(kafkaReq)
+	}))
+}
+
+func newKafkaAPI(url string) kafkamgmtclient.DefaultApi {
+	cfg := kafkamgmtclient.NewConfiguration()
+	cfg.Servers = kafkamgmtclient.ServerConfigurations{{URL: url}}
+
+	return kafkamgmtclient.NewAPIClient(cfg).DefaultApi
+}
+
+// setWaitIntervals overrides the polling intervals for the duration of the test
+func setWaitIntervals(t *testing.T, initial time.Duration, max time.Duration) {
+	prevInitial, prevMax := WaitInitialInterval, WaitMaxInterval
+	t.Cleanup(func() {
+		WaitInitialInterval, WaitMaxInterval = prevInitial, prevMax
+	})
+
+	WaitInitialInterval, WaitMaxInterval = initial, max
+}
+
+func TestWaitForReady(t *testing.T) {
+	setWaitIntervals(t, time.Millisecond, time.Millisecond)
+
+	tests := []struct {
+		name       string
+		statuses   []string
+		timeout    time.Duration
+		wantStatus string
+		wantErr    error
+	}{
+		{
+			name:       "Should return when the instance becomes ready",
+			statuses:   []string{StatusAccepted, StatusPreparing, StatusProvisioning, StatusReady},
+			timeout:    time.Minute,
+			wantStatus: StatusReady,
+		},
+		{
+			name:       "Should return when the instance has failed",
+			statuses:   []string{StatusAccepted, StatusFailed},
+			timeout:    time.Minute,
+			wantStatus: StatusFailed,
+		},
+		{
+			name:       "Should return when the instance is being deleted",
+			statuses:   []string{StatusProvisioning, StatusDeprovision},
+			timeout:    time.Minute,
+			wantStatus: StatusDeprovision,
+		},
+		{
+			name:       "Should return when the instance is deleting",
+			statuses:   []string{StatusDeleting},
+			timeout:    time.Minute,
+			wantStatus: StatusDeleting,
+		},
+		{
+			name:     "Should return the context error when the timeout is exceeded",
+			statuses: []string{StatusProvisioning},
+			timeout:  50 * time.Millisecond,
+			wantErr:  context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			server := newKafkaStatusServer(tt.statuses...)
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			polls := 0
+			got, err := WaitForReady(ctx, newKafkaAPI(server.URL), "1", func(*kafkamgmtclient.KafkaRequest) {
+				polls++
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WaitForReady() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.GetStatus() != tt.wantStatus {
+				t.Errorf("WaitForReady() status = %v, want %v", got.GetStatus(), tt.wantStatus)
+			}
+			if polls != len(tt.statuses) {
+				t.Errorf("WaitForReady() polled %v times, want %v", polls, len(tt.statuses))
+			}
+		})
+	}
+}
+
+func TestNextWaitInterval(t *testing.T) {
+	setWaitIntervals(t, WaitInitialInterval, 30*time.Second)
+
+	if got := nextWaitInterval(10 * time.Second); got != 15*time.Second {
+		t.Errorf("nextWaitInterval() = %v, want %v", got, 15*time.Second)
+	}
+	if got := nextWaitInterval(25 * time.Second); got != WaitMaxInterval {
+		t.Errorf("nextWaitInterval() = %v, want %v", got, WaitMaxInterval)
+	}
+}
@@ -0,0 +1,56 @@
+// Package preflight contains the permission checks which mutating commands
+// run before making any changes, so that access problems are reported
+// up front instead of failing halfway through an operation.
+package preflight
+
+import (
+	"errors"
+
+	"github.com/aerogear/charmil-host-example/pkg/ams"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"github.com/spf13/cobra"
+)
+
+// FlagSkipPreflight is the name of the flag used to bypass the pre-flight checks
+const FlagSkipPreflight = "skip-preflight"
+
+// Permission is an action on an AMS resource type which a command requires
+type Permission struct {
+	Action       string
+	ResourceType string
+}
+
+// Permissions required by the mutating commands
+var (
+	CreateKafka          = Permission{Action: "create", ResourceType: "ManagedKafka"}
+	DeleteKafka          = Permission{Action: "delete", ResourceType: "ManagedKafka"}
+	CreateServiceAccount = Permission{Action: "create", ResourceType: "ServiceAccount"}
+	UpdateServiceAccount = Permission{Action: "update", ResourceType: "ServiceAccount"}
+	DeleteServiceAccount = Permission{Action: "delete", ResourceType: "ServiceAccount"}
+)
+
+// AddFlag adds the --skip-preflight flag to the command
+func AddFlag(cmd *cobra.Command, skip *bool, localizer localize.Localizer) {
+	cmd.Flags().BoolVar(skip, FlagSkipPreflight, false, localizer.LocalizeByID("preflight.flag.skipPreflight.description"))
+}
+
+// Check verifies that the current user has been granted all of the permissions.
+// An error explaining which permission is missing is returned for the first denied check.
+func Check(conn connection.Connection, localizer localize.Localizer, permissions ...Permission) error {
+	for _, p := range permissions {
+		actionEntry := localize.NewEntry("Action", p.Action)
+		resourceEntry := localize.NewEntry("ResourceType", p.ResourceType)
+
+		allowed, err := ams.CheckUserAccess(conn, p.Action, p.ResourceType)
+		if err != nil {
+			return errors.New(localizer.LocalizeByID("preflight.error.reviewFailed", actionEntry, resourceEntry, localize.NewEntry("ErrorMessage", err)))
+		}
+
+		if !allowed {
+			return errors.New(localizer.LocalizeByID("preflight.error.accessDenied", actionEntry, resourceEntry))
+		}
+	}
+
+	return nil
+}
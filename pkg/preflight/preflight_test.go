@@ -0,0 +1,78 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aerogear/charmil-host-example/pkg/api"
+	"github.com/aerogear/charmil-host-example/pkg/api/ams/amsclient"
+	"github.com/aerogear/charmil-host-example/pkg/connection"
+	"github.com/aerogear/charmil-host-example/pkg/localesettings"
+	"github.com/aerogear/charmil/core/utils/localize"
+	"golang.org/x/text/language"
+)
+
+func newConnectionMock(allowed bool, reviewErr error) connection.Connection {
+	amsMock := &amsclient.DefaultApiMock{}
+	amsMock.ApiAuthorizationsV1SelfAccessReviewPostFunc = func(ctx context.Context) amsclient.ApiApiAuthorizationsV1SelfAccessReviewPostRequest {
+		return amsclient.ApiApiAuthorizationsV1SelfAccessReviewPostRequest{ApiService: amsMock}
+	}
+	amsMock.ApiAuthorizationsV1SelfAccessReviewPostExecuteFunc = func(r amsclient.ApiApiAuthorizationsV1SelfAccessReviewPostRequest) (amsclient.AccessReviewResponse, *http.Response, error) {
+		return amsclient.AccessReviewResponse{Allowed: allowed}, nil, reviewErr
+	}
+
+	return &connection.ConnectionMock{
+		APIFunc: func() *api.API {
+			return &api.API{
+				AccountMgmt: func() amsclient.DefaultApi {
+					return amsMock
+				},
+			}
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	locConfig := &localize.Config{
+		Language: &language.English,
+		Files:    localesettings.DefaultLocales,
+		Format:   "toml",
+	}
+
+	localizer, _ := localize.New(locConfig)
+
+	tests := []struct {
+		name      string
+		allowed   bool
+		reviewErr error
+		wantErr   bool
+	}{
+		{
+			name:    "Should pass when the action is allowed",
+			allowed: true,
+			wantErr: false,
+		},
+		{
+			name:    "Should fail when the action is denied",
+			allowed: false,
+			wantErr: true,
+		},
+		{
+			name:      "Should fail when the access review request fails",
+			allowed:   true,
+			reviewErr: errors.New("service unavailable"),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		// nolint:scopelint
+		t.Run(tt.name, func(t *testing.T) {
+			conn := newConnectionMock(tt.allowed, tt.reviewErr)
+			if err := Check(conn, localizer, CreateKafka); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package credentials
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/aerogear/charmil-host-example/pkg/dump"
 
@@ -79,6 +83,63 @@ func Write(output string, filepath string, credentials *Credentials) error {
 	return ioutil.WriteFile(trueFilePath, fileData, 0o600)
 }
 
+// Read loads the credentials from a file written by Write,
+// detecting whether it is in the JSON, env or properties format
+func Read(filepath string) (*Credentials, error) {
+	data, err := ioutil.ReadFile(os.ExpandEnv(filepath))
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if credentials.ClientID == "" || credentials.ClientSecret == "" {
+		return nil, fmt.Errorf("could not find a client ID and client secret in %v", filepath)
+	}
+
+	return credentials, nil
+}
+
+func parse(data []byte) (*Credentials, error) {
+	credentials := &Credentials{}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var fields map[string]string
+		if err := json.Unmarshal(trimmed, &fields); err != nil {
+			return nil, err
+		}
+		for key, value := range fields {
+			credentials.set(key, value)
+		}
+		return credentials, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, "="); i > 0 {
+			credentials.set(strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]))
+		}
+	}
+
+	return credentials, scanner.Err()
+}
+
+// set assigns a field of the credentials, accepting the keys of all file formats
+func (c *Credentials) set(key string, value string) {
+	switch strings.ToLower(strings.ReplaceAll(key, "_", "")) {
+	case "clientid":
+		c.ClientID = value
+	case "clientsecret":
+		c.ClientSecret = value
+	}
+}
+
 func getFileFormat(output string) (format string) {
 	switch output {
 	case "env":
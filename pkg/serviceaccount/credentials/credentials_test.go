@@ -0,0 +1,39 @@
+package credentials
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestReadWrittenCredentials(t *testing.T) {
+	want := &Credentials{ClientID: "srvc-acct-1234", ClientSecret: "s3cr3t"}
+
+	for _, format := range []string{"json", "env", "properties"} {
+		// nolint:scopelint
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials")
+			if err := Write(format, path, want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Read(path)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if *got != *want {
+				t.Errorf("Read() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadIncompleteCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := Write("env", path, &Credentials{ClientID: "srvc-acct-1234"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Read(path); err == nil {
+		t.Error("Read() expected an error for a file without a client secret")
+	}
+}
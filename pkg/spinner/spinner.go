@@ -0,0 +1,111 @@
+// Package spinner contains a progress indicator for long running operations
+package spinner
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aerogear/charmil/core/utils/iostreams"
+)
+
+var frames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const frameInterval = 100 * time.Millisecond
+
+// Spinner prints a message followed by the elapsed time to the error stream.
+// When the stream is a terminal the line is animated and redrawn in place,
+// otherwise a new line is printed only when the message changes.
+type Spinner struct {
+	out   io.Writer
+	isTTY bool
+
+	mu      sync.Mutex
+	message string
+	started time.Time
+	frame   int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates a spinner which writes to the error stream of the IOStreams.
+// The elapsed time is counted from its creation until it is started.
+func New(io *iostreams.IOStreams) *Spinner {
+	return &Spinner{
+		out:     io.ErrOut,
+		isTTY:   io.IsStderrTTY(),
+		started: time.Now(),
+	}
+}
+
+// Start starts the spinner and the elapsed time counter
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	s.started = time.Now()
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+
+	if !s.isTTY {
+		close(s.done)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(frameInterval)
+		defer ticker.Stop()
+		defer close(s.done)
+
+		for {
+			s.draw()
+
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// SetMessage updates the message shown next to the spinner
+func (s *Spinner) SetMessage(message string) {
+	s.mu.Lock()
+	changed := s.message != message
+	s.message = message
+	s.mu.Unlock()
+
+	if changed && !s.isTTY {
+		fmt.Fprintf(s.out, "%v (%v)\n", message, s.Elapsed())
+	}
+}
+
+// Elapsed returns the time since the spinner was started, rounded to the second
+func (s *Spinner) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return time.Since(s.started).Round(time.Second)
+}
+
+// Stop stops the spinner and clears its line from the terminal
+func (s *Spinner) Stop() {
+	close(s.stop)
+	<-s.done
+
+	if s.isTTY {
+		fmt.Fprint(s.out, "\r\033[K")
+	}
+}
+
+func (s *Spinner) draw() {
+	elapsed := s.Elapsed()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(s.out, "\r\033[K%v %v (%v)", frames[s.frame], s.message, elapsed)
+	s.frame = (s.frame + 1) % len(frames)
+}
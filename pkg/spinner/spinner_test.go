@@ -0,0 +1,24 @@
+package spinner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aerogear/charmil/core/utils/iostreams"
+)
+
+func TestSetMessageBeforeStart(t *testing.T) {
+	out := &bytes.Buffer{}
+	io := &iostreams.IOStreams{ErrOut: out}
+	io.SetStderrTTY(false)
+
+	s := New(io)
+	s.SetMessage("waiting")
+	s.Start()
+	s.Stop()
+
+	if got := strings.TrimSpace(out.String()); got != "waiting (0s)" {
+		t.Errorf("SetMessage() printed %q, want %q", got, "waiting (0s)")
+	}
+}